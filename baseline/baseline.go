@@ -0,0 +1,331 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/synchro-food/filelint/lib"
+	"github.com/synchro-food/filelint/lint"
+)
+
+const DefaultLocation = ".filelint-baseline.json"
+
+const version = 1
+
+// Baseline remembers the existing lint errors which should not be reported.
+// A lint error is identified by the file, the rule and the fingerprint of the
+// content of the reported line, so that it survives line shifts.
+// It is safe for concurrent use.
+type Baseline struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string][]*Entry
+	dirty   bool
+}
+
+type Entry struct {
+	Rule        string `json:"rule"`
+	Fingerprint string `json:"fingerprint"`
+
+	// Count is the number of the lint errors which have the same fingerprint
+	Count int `json:"count"`
+}
+
+type baselineFile struct {
+	Version int                 `json:"version"`
+	Files   map[string][]*Entry `json:"files"`
+}
+
+type key struct {
+	rule        string
+	fingerprint string
+}
+
+// New returns the empty baseline which will be written at path.
+func New(path string) *Baseline {
+	return &Baseline{
+		path:    path,
+		entries: make(map[string][]*Entry),
+		dirty:   true,
+	}
+}
+
+// Load loads the baseline file at path.
+// It returns nil if the file doesn't exist.
+func Load(path string) (*Baseline, error) {
+	if !lib.IsExist(path) {
+		return nil, nil
+	}
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var bf baselineFile
+	if err := json.Unmarshal(src, &bf); err != nil {
+		return nil, fmt.Errorf("%s: broken baseline file: %v", path, err)
+	}
+	if bf.Version != version {
+		return nil, fmt.Errorf("%s: unsupported baseline version %d", path, bf.Version)
+	}
+
+	b := &Baseline{
+		path:    path,
+		entries: bf.Files,
+	}
+	if b.entries == nil {
+		b.entries = make(map[string][]*Entry)
+	}
+
+	return b, nil
+}
+
+// Add records the lint errors of file into the baseline.
+func (b *Baseline) Add(file string, reports []*lint.Report) error {
+	if len(reports) == 0 {
+		return nil
+	}
+
+	keys, err := keysOf(file, reports)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, k := range keys {
+		b.entries[file] = addEntry(b.entries[file], k, 1)
+	}
+	b.dirty = true
+
+	return nil
+}
+
+// Filter returns the lint errors of file which are not in the baseline.
+// rules is the names of the rules which linted file. The entries of the rules
+// which are no longer reported are pruned, and the entries of the other rules
+// are kept since their errors may remain.
+func (b *Baseline) Filter(file string, rules []string, reports []*lint.Report) ([]*lint.Report, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entries, ok := b.entries[file]
+	if !ok {
+		return reports, nil
+	}
+
+	keys, err := keysOf(file, reports)
+	if err != nil {
+		return nil, err
+	}
+
+	remains := make(map[key]int, len(entries))
+	for _, e := range entries {
+		remains[key{e.Rule, e.Fingerprint}] += e.Count
+	}
+
+	var (
+		filtered = make([]*lint.Report, 0, len(reports))
+		matched  []*Entry
+	)
+	linted := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		linted[r] = true
+	}
+	for _, e := range entries {
+		if !linted[e.Rule] {
+			matched = addEntry(matched, key{e.Rule, e.Fingerprint}, e.Count)
+		}
+	}
+	for i, k := range keys {
+		if remains[k] > 0 {
+			remains[k]--
+			matched = addEntry(matched, k, 1)
+			continue
+		}
+		filtered = append(filtered, reports[i])
+	}
+
+	if !sameEntries(entries, matched) {
+		if len(matched) == 0 {
+			delete(b.entries, file)
+		} else {
+			b.entries[file] = matched
+		}
+		b.dirty = true
+	}
+
+	return filtered, nil
+}
+
+// Len returns the number of the lint errors in the baseline.
+func (b *Baseline) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := 0
+	for _, entries := range b.entries {
+		for _, e := range entries {
+			n += e.Count
+		}
+	}
+	return n
+}
+
+// Save writes the baseline file if it has been changed.
+func (b *Baseline) Save() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.dirty {
+		return nil
+	}
+
+	for _, entries := range b.entries {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].Rule != entries[j].Rule {
+				return entries[i].Rule < entries[j].Rule
+			}
+			return entries[i].Fingerprint < entries[j].Fingerprint
+		})
+	}
+
+	// the baseline is indented to be reviewed in diffs
+	src, err := json.MarshalIndent(&baselineFile{
+		Version: version,
+		Files:   b.entries,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	src = append(src, '\n')
+
+	if err := lib.WriteFileAtomic(b.path, src, 0644); err != nil {
+		return err
+	}
+
+	b.dirty = false
+
+	return nil
+}
+
+func addEntry(entries []*Entry, k key, n int) []*Entry {
+	for _, e := range entries {
+		if e.Rule == k.rule && e.Fingerprint == k.fingerprint {
+			e.Count += n
+			return entries
+		}
+	}
+	return append(entries, &Entry{Rule: k.rule, Fingerprint: k.fingerprint, Count: n})
+}
+
+func sameEntries(a, b []*Entry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[key]int, len(a))
+	for _, e := range a {
+		counts[key{e.Rule, e.Fingerprint}] += e.Count
+	}
+	for _, e := range b {
+		counts[key{e.Rule, e.Fingerprint}] -= e.Count
+	}
+	for _, n := range counts {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// keysOf returns the keys of the reports.
+// The fingerprint of a report is the hash of its line in the source linted by
+// the rule, or the hash of its message if it is about the whole file. The
+// lines are read from file if the source is not kept.
+func keysOf(file string, reports []*lint.Report) ([]key, error) {
+	keys := make([]key, len(reports))
+	if len(reports) == 0 {
+		return keys, nil
+	}
+
+	lines, err := readLines(file, reports)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range reports {
+		var content string
+		if r.Line() > 0 {
+			text, ok := r.LineText()
+			if !ok {
+				text = lines[r.Line()]
+			}
+			content = "line:" + text
+		} else {
+			content = "file:" + r.Message()
+		}
+		sum := sha256.Sum256([]byte(content))
+		keys[i] = key{
+			rule:        r.Rule(),
+			fingerprint: hex.EncodeToString(sum[:8]),
+		}
+	}
+
+	return keys, nil
+}
+
+// readLines reads the reported lines of file which are not kept by the
+// reports.
+func readLines(file string, reports []*lint.Report) (map[int]string, error) {
+	lines := make(map[int]string)
+	max := 0
+	for _, r := range reports {
+		if _, ok := r.LineText(); ok {
+			continue
+		}
+		if n := r.Line(); n > 0 {
+			lines[n] = ""
+			if n > max {
+				max = n
+			}
+		}
+	}
+	if max == 0 {
+		return lines, nil
+	}
+
+	fp, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	lr := lint.NewLineReader(fp)
+	for {
+		l, err := lr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := lines[l.Num]; ok {
+			lines[l.Num] = string(l.Text)
+		}
+		if l.Num >= max {
+			break
+		}
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,225 @@
+package baseline
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/synchro-food/filelint/filetype"
+	"github.com/synchro-food/filelint/lint"
+)
+
+func newTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "filelint-baseline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// defaultRules is the rules linting files in the tests.
+var defaultRules = map[string]map[string]interface{}{
+	"no-eol-space":  {},
+	"final-newline": {},
+}
+
+// lintFile writes src to file and returns the reports of the rules and the
+// names of the rules which linted file.
+func lintFile(t *testing.T, file, src string, ops map[string]map[string]interface{}) ([]*lint.Report, []string) {
+	if err := ioutil.WriteFile(file, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var rules []lint.Rule
+	for name, op := range ops {
+		rule, err := lint.GetDefinedRules().Get(name).New(op)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, rule)
+	}
+
+	typ, _ := filetype.DetectSource(file, []byte(src))
+	linter, err := lint.NewLinter(file, typ, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := linter.Lint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.Reports, linter.LintedRules()
+}
+
+// lintReports is like lintFile, but returns only the reports of the default
+// rules.
+func lintReports(t *testing.T, file, src string) []*lint.Report {
+	reports, _ := lintFile(t, file, src, defaultRules)
+	return reports
+}
+
+func reportStrings(reports []*lint.Report) []string {
+	ss := []string{}
+	for _, r := range reports {
+		ss = append(ss, r.String())
+	}
+	return ss
+}
+
+func TestBaseline(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, DefaultLocation)
+	file := filepath.Join(dir, "a.txt")
+
+	b := New(path)
+	assert.NoError(t, b.Add(file, lintReports(t, file, "a \nb\na \nc\t")))
+	assert.Equal(t, 4, b.Len())
+	assert.NoError(t, b.Save())
+
+	tests := []struct {
+		src  string
+		want []string
+		len  int
+	}{
+		{
+			// the lines are shifted
+			src:  "x\ny\na \nb\na \nc\t",
+			want: []string{},
+			len:  4,
+		},
+		{
+			// the new lint errors are reported
+			src:  "a \nb \na \nc\t",
+			want: []string{"2:2: Trailing spaces/tabs at the end of lines are disallowed"},
+			len:  4,
+		},
+		{
+			// the fixed lint errors are pruned
+			src:  "a \nb\na\nc\t",
+			want: []string{},
+			len:  3,
+		},
+		{
+			// the pruned lint errors are reported again
+			src:  "a \nb\na \nc\t",
+			want: []string{"3:2: Trailing spaces/tabs at the end of lines are disallowed"},
+			len:  3,
+		},
+		{
+			src:  "a\nb\n",
+			want: []string{},
+			len:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		b, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reports, rules := lintFile(t, file, tt.src, defaultRules)
+		got, err := b.Filter(file, rules, reports)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, reportStrings(got), tt.src)
+		assert.Equal(t, tt.len, b.Len(), tt.src)
+		assert.NoError(t, b.Save())
+	}
+}
+
+func TestBaseline_FilterAll(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "a.txt")
+
+	b := New(filepath.Join(dir, DefaultLocation))
+	assert.NoError(t, b.Add(file, lintReports(t, file, "a \n")))
+
+	// other files are not affected
+	reports, rules := lintFile(t, file, "a \n", defaultRules)
+	got, err := b.Filter("b.txt", rules, reports)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, 1, b.Len())
+
+	// the fixed file has no entries
+	got, err = b.Filter(file, rules, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestBaseline_FilterRules(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "a.txt")
+
+	b := New(filepath.Join(dir, DefaultLocation))
+	assert.NoError(t, b.Add(file, lintReports(t, file, "a \nb")))
+	assert.Equal(t, 2, b.Len())
+
+	// the entries of the rules which didn't lint the file are kept, such as
+	// the rules disabled by --only or --disable
+	reports, rules := lintFile(t, file, "a \nb", map[string]map[string]interface{}{"no-eol-space": {}})
+	got, err := b.Filter(file, rules, reports)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	assert.Equal(t, 2, b.Len())
+
+	reports, rules = lintFile(t, file, "a\nb", map[string]map[string]interface{}{"no-eol-space": {}})
+	got, err = b.Filter(file, rules, reports)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	assert.Equal(t, 1, b.Len())
+
+	got, err = b.Filter(file, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+	assert.Equal(t, 1, b.Len())
+}
+
+func TestBaseline_FixedSource(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "a.txt")
+
+	b := New(filepath.Join(dir, DefaultLocation))
+	assert.NoError(t, b.Add(file, lintReports(t, file, "a \nb\n")))
+
+	// the header inserted by the preceding rule shifts the reported line,
+	// which is fingerprinted in the source linted by the rule
+	reports, rules := lintFile(t, file, "a \nb\n", map[string]map[string]interface{}{
+		"header":        {"template": "License: MIT", "comment-style": "hash"},
+		"no-eol-space":  {},
+		"final-newline": {},
+	})
+	assert.Equal(t, []string{"1:0: Header is missing", "3:2: Trailing spaces/tabs at the end of lines are disallowed"}, reportStrings(reports))
+
+	got, err := b.Filter(file, rules, reports)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1:0: Header is missing"}, reportStrings(got))
+}
+
+func TestLoad(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, DefaultLocation)
+
+	b, err := Load(path)
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+
+	if err := ioutil.WriteFile(path, []byte(`{"version": 2}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = Load(path)
+	assert.Error(t, err)
+}
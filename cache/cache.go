@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/synchro-food/filelint/config"
+	"github.com/synchro-food/filelint/lib"
+)
+
+const DefaultLocation = ".filelintcache"
+
+// Cache remembers files which had no lint errors in the previous run.
+// It is safe for concurrent use.
+type Cache struct {
+	path       string
+	version    string
+	configHash string
+
+	mu      sync.Mutex
+	entries map[string]*Entry
+	dirty   bool
+}
+
+type Entry struct {
+	Content string `json:"content"`
+	Rules   string `json:"rules"`
+}
+
+type cacheFile struct {
+	Version string            `json:"version"`
+	Config  string            `json:"config"`
+	Entries map[string]*Entry `json:"entries"`
+}
+
+// New loads the cache file at path.
+// The stored entries are discarded if they were written by another version of
+// filelint or with another configuration.
+func New(path, version string, cfg *config.Config) (*Cache, error) {
+	configHash, err := hashYAML(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		path:       path,
+		version:    version,
+		configHash: configHash,
+		entries:    make(map[string]*Entry),
+	}
+
+	if !lib.IsExist(path) {
+		return c, nil
+	}
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cf cacheFile
+	if err := json.Unmarshal(src, &cf); err != nil {
+		// a broken cache is the same as no cache
+		c.dirty = true
+		return c, nil
+	}
+
+	if cf.Version != version || cf.Config != configHash || cf.Entries == nil {
+		c.dirty = true
+		return c, nil
+	}
+
+	c.entries = cf.Entries
+
+	return c, nil
+}
+
+// Has reports whether file was clean in the previous run with the same
+// content and the same rules.
+func (c *Cache) Has(file string, entry *Entry) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[file]
+	if !ok {
+		return false
+	}
+	return *e == *entry
+}
+
+func (c *Cache) Set(file string, entry *Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[file]; ok && *e == *entry {
+		return
+	}
+	c.entries[file] = entry
+	c.dirty = true
+}
+
+func (c *Cache) Delete(file string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[file]; !ok {
+		return
+	}
+	delete(c.entries, file)
+	c.dirty = true
+}
+
+// Save writes the cache file if it has been changed.
+// The file is replaced atomically so that concurrent runs never read a
+// partially written cache.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	src, err := json.Marshal(&cacheFile{
+		Version: c.version,
+		Config:  c.configHash,
+		Entries: c.entries,
+	})
+	if err != nil {
+		return err
+	}
+	src = append(src, '\n')
+
+	if err := lib.WriteFileAtomic(c.path, src, 0644); err != nil {
+		return err
+	}
+
+	c.dirty = false
+
+	return nil
+}
+
+// NewEntry makes the cache entry of file linted with rules.
+func NewEntry(file string, rules config.RuleMap) (*Entry, error) {
+	fp, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, fp); err != nil {
+		return nil, err
+	}
+
+	rulesHash, err := hashYAML(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Entry{
+		Content: hex.EncodeToString(h.Sum(nil)),
+		Rules:   rulesHash,
+	}, nil
+}
+
+// WithContent returns a copy of the entry for the new content of the file.
+func (e *Entry) WithContent(src []byte) *Entry {
+	sum := sha256.Sum256(src)
+	return &Entry{
+		Content: hex.EncodeToString(sum[:]),
+		Rules:   e.Rules,
+	}
+}
+
+func hashYAML(v interface{}) (string, error) {
+	// yaml.v2 sorts map keys, so the same value always has the same hash
+	src, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(src)
+	return hex.EncodeToString(sum[:]), nil
+}
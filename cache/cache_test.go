@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/synchro-food/filelint/config"
+)
+
+func newTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "filelint-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestCache_SaveAndLoad(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, DefaultLocation)
+	cfg := &config.Config{File: config.File{Include: []string{"**/*"}}}
+	entry := &Entry{Content: "a", Rules: "b"}
+
+	c, err := New(path, "1.0.0", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("a.txt", entry)
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		version string
+		cfg     *config.Config
+		want    bool
+	}{
+		{"1.0.0", cfg, true},
+		{"1.0.1", cfg, false},
+		{"1.0.0", &config.Config{File: config.File{Include: []string{"*"}}}, false},
+	}
+
+	for _, tt := range tests {
+		c, err := New(path, tt.version, tt.cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.want, c.Has("a.txt", entry))
+		assert.False(t, c.Has("a.txt", &Entry{Content: "c", Rules: "b"}))
+		assert.False(t, c.Has("b.txt", entry))
+	}
+}
+
+func TestCache_Broken(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, DefaultLocation)
+	if err := ioutil.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := New(path, "1.0.0", &config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, c.Has("a.txt", &Entry{}))
+	assert.NoError(t, c.Save())
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	c, err := New(filepath.Join(dir, DefaultLocation), "1.0.0", &config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			file := strconv.Itoa(i)
+			c.Set(file, &Entry{Content: file})
+			c.Has(file, &Entry{Content: file})
+			if i%2 == 0 {
+				c.Delete(file)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, c.entries, 50)
+}
+
+func TestNewEntry(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "a.txt")
+	if err := ioutil.WriteFile(file, []byte("a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := config.RuleMap{"no-bom": {"enforce": true}}
+	e1, err := NewEntry(file, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e2, err := NewEntry(file, config.RuleMap{"no-bom": {"enforce": false}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, e1.Content, e2.Content)
+	assert.NotEqual(t, e1.Rules, e2.Rules)
+	assert.Equal(t, e1, e1.WithContent([]byte("a\n")))
+	assert.NotEqual(t, e1, e1.WithContent([]byte("b\n")))
+}
@@ -1,14 +1,41 @@
 package cli
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/synchro-food/filelint/config"
+	"github.com/synchro-food/filelint/dispatcher"
+)
 
 var (
 	errLintFailed = errors.New("lint errors detected")
+	errFixed      = errors.New("lint errors autofixed")
 )
 
+// The exit statuses of filelint. The status is 0 if no lint errors are
+// detected.
 const (
-	DefaultExitStatus    = 2
+	// LintFailedExitStatus is the status when lint errors are detected
 	LintFailedExitStatus = 1
+
+	// UsageExitStatus is the status of invalid flags and arguments, which
+	// prints the usage
+	UsageExitStatus = 2
+
+	// ConfigErrorExitStatus is the status of invalid config files and rules
+	ConfigErrorExitStatus = 3
+
+	// IOErrorExitStatus is the status when files can't be read or written
+	IOErrorExitStatus = 4
+
+	// InternalErrorExitStatus is the status of the bugs of filelint
+	InternalErrorExitStatus = 5
+
+	// FixedExitStatus is the status when lint errors are autofixed with
+	// --exit-on-fix flag
+	FixedExitStatus = 6
 )
 
 type ExitError interface {
@@ -18,29 +45,130 @@ type ExitError interface {
 
 type exitError struct {
 	exitStatus int
-	message    string
+	err        error
 }
 
 func (ee *exitError) Error() string {
-	return ee.message
+	return ee.err.Error()
+}
+
+func (ee *exitError) Unwrap() error {
+	return ee.err
 }
 
 func (ee *exitError) ExitStatus() int {
 	return ee.exitStatus
 }
 
-func Raise(err error) ExitError {
-	var exitStatus int
+// UsageError is an invalid flag or argument.
+type UsageError struct {
+	Err error
+}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return &UsageError{Err: fmt.Errorf(format, args...)}
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigError is an error of the config such as an invalid config file.
+type ConfigError struct {
+	// Location is the config file or the flag, or empty if Err has it
+	Location string
+
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	if e.Location == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", e.Location, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// IOError is an error reading or writing the file.
+type IOError struct {
+	// File is the file, or empty if Err has it
+	File string
+
+	Err error
+}
+
+func (e *IOError) Error() string {
+	// the errors of package os have the file already
+	var pe *os.PathError
+	if e.File == "" || errors.As(e.Err, &pe) {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", e.File, e.Err)
+}
+
+func (e *IOError) Unwrap() error {
+	return e.Err
+}
 
-	switch err {
-	case errLintFailed:
-		exitStatus = LintFailedExitStatus
-	default:
-		exitStatus = DefaultExitStatus
+// InternalError is a bug of filelint, such as a panic.
+type InternalError struct {
+	Err error
+}
+
+func (e *InternalError) Error() string {
+	return fmt.Sprintf("internal error: %v", e.Err)
+}
+
+func (e *InternalError) Unwrap() error {
+	return e.Err
+}
+
+func Raise(err error) ExitError {
+	if ee, ok := err.(ExitError); ok {
+		return ee
 	}
 
 	return &exitError{
-		exitStatus: exitStatus,
-		message:    err.Error(),
+		exitStatus: exitStatus(err),
+		err:        err,
+	}
+}
+
+// exitStatus returns the exit status of the error. The errors which are not
+// classified are internal errors.
+func exitStatus(err error) int {
+	var (
+		usageError      *UsageError
+		configError     *ConfigError
+		validationError config.ValidationErrors
+		globError       *config.GlobError
+		ruleError       *dispatcher.RuleError
+		ioError         *IOError
+		pathError       *os.PathError
+		linkError       *os.LinkError
+		syscallError    *os.SyscallError
+	)
+
+	switch {
+	case err == errLintFailed:
+		return LintFailedExitStatus
+	case err == errFixed:
+		return FixedExitStatus
+	case errors.As(err, &usageError):
+		return UsageExitStatus
+	case errors.As(err, &configError), errors.As(err, &validationError),
+		errors.As(err, &globError), errors.As(err, &ruleError):
+		return ConfigErrorExitStatus
+	case errors.As(err, &ioError), errors.As(err, &pathError),
+		errors.As(err, &linkError), errors.As(err, &syscallError):
+		return IOErrorExitStatus
 	}
+	return InternalErrorExitStatus
 }
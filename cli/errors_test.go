@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/synchro-food/filelint/config"
+	"github.com/synchro-food/filelint/dispatcher"
+)
+
+func TestRaise(t *testing.T) {
+	pathError := &os.PathError{Op: "open", Path: "a.txt", Err: os.ErrPermission}
+
+	tests := []struct {
+		err    error
+		status int
+		msg    string
+	}{
+		{errLintFailed, LintFailedExitStatus, "lint errors detected"},
+		{errFixed, FixedExitStatus, "lint errors autofixed"},
+		{usageErrorf("--for must be used with --print-config"), UsageExitStatus, "--for must be used with --print-config"},
+		{&ConfigError{Location: ".filelint.yml", Err: ErrNoSuchConfigFile}, ConfigErrorExitStatus, ".filelint.yml: no such config file"},
+		{
+			config.ValidationErrors{{File: ".filelint.yml", Position: config.Position{Line: 2, Column: 3}, Message: "unknown key \"x\""}},
+			ConfigErrorExitStatus,
+			`.filelint.yml:2:3: unknown key "x"`,
+		},
+		{&config.GlobError{Pattern: "[a", Reason: "unclosed '['"}, ConfigErrorExitStatus, `invalid glob pattern "[a": unclosed '['`},
+		{
+			&dispatcher.RuleError{File: "a.txt", Rule: "header", Origin: ".filelint.yml:4:7 targets[0].rules.header", Err: errors.New("header.template is required")},
+			ConfigErrorExitStatus,
+			".filelint.yml:4:7 targets[0].rules.header: header.template is required (applied to a.txt)",
+		},
+		{pathError, IOErrorExitStatus, "open a.txt: permission denied"},
+		{&IOError{File: "a.txt", Err: pathError}, IOErrorExitStatus, "open a.txt: permission denied"},
+		{&IOError{File: "stdin", Err: errors.New("broken pipe")}, IOErrorExitStatus, "stdin: broken pipe"},
+		{fmt.Errorf("walk: %w", pathError), IOErrorExitStatus, "walk: open a.txt: permission denied"},
+		{&InternalError{Err: errors.New("oops")}, InternalErrorExitStatus, "internal error: oops"},
+		{errors.New("unknown"), InternalErrorExitStatus, "unknown"},
+	}
+
+	for _, tt := range tests {
+		ee := Raise(tt.err)
+		assert.Equal(t, tt.status, ee.ExitStatus(), tt.msg)
+		assert.EqualError(t, ee, tt.msg)
+
+		// the raised errors are not raised again
+		assert.Equal(t, ee, Raise(ee))
+	}
+}
+
+func TestExecuteC_Panic(t *testing.T) {
+	cmd := rootCmd
+	rootCmd.SetArgs([]string{"--version"})
+	defer rootCmd.SetArgs(nil)
+	defer func() { isShowVersion = false }()
+
+	// a panic in the command is an internal error
+	rootCmd.PreRun = func(*cobra.Command, []string) { panic("oops") }
+	defer func() { rootCmd.PreRun = nil }()
+
+	got, err := executeC(cmd)
+	assert.Equal(t, cmd, got)
+	assert.Equal(t, InternalErrorExitStatus, Raise(err).ExitStatus())
+	assert.Contains(t, err.Error(), "internal error: oops")
+}
+
+func TestExecuteC_FlagError(t *testing.T) {
+	rootCmd.SetArgs([]string{"--bogus"})
+	defer rootCmd.SetArgs(nil)
+
+	_, err := executeC(rootCmd)
+	assert.Equal(t, UsageExitStatus, Raise(err).ExitStatus())
+	assert.EqualError(t, err, "unknown flag: --bogus")
+}
@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/synchro-food/filelint/config"
+	"github.com/synchro-food/filelint/ignore"
+	"github.com/synchro-food/filelint/lib"
+	"github.com/synchro-food/filelint/lint"
+)
+
+var explainCmd = &cobra.Command{
+	Use:           "explain <file>",
+	Short:         "print why the file is linted or not and the effective rule options",
+	RunE:          executeExplain,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	explainCmd.Flags().StringVarP(&configFile, "config", "c", "", "specify configuration file")
+	explainCmd.Flags().BoolVar(&useDefaultConfig, "no-config", false, "don't use config file (use the application default config)")
+	explainCmd.Flags().BoolVar(&useGitIgnore, "use-gitignore", true, "read and use .gitignore files for excluding target files")
+	explainCmd.Flags().StringVar(&ignorePath, "ignore-path", "", "specify the file to use instead of .filelintignore files")
+	commandRoot.AddCommand(explainCmd)
+}
+
+func executeExplain(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return Raise(usageErrorf("explain requires exactly one file"))
+	}
+
+	file, err := relativePath(args[0])
+	if err != nil {
+		return Raise(err)
+	}
+	fi, err := os.Stat(file)
+	if err != nil {
+		return Raise(err)
+	}
+	if fi.IsDir() {
+		return Raise(usageErrorf("%s is a directory", file))
+	}
+
+	cfg, err := loadConfig(configFile, useDefaultConfig)
+	if err != nil {
+		return Raise(err)
+	}
+
+	ignores, err := loadIgnores(ignorePath, useGitIgnore)
+	if err != nil {
+		return Raise(err)
+	}
+
+	if err := explain(os.Stdout, cfg, ignores, file); err != nil {
+		return Raise(err)
+	}
+	return nil
+}
+
+// relativePath returns the cleaned path relative to the current directory,
+// since the patterns in the config are relative to it.
+func relativePath(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return filepath.Clean(path), nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil {
+		return "", err
+	}
+	return rel, nil
+}
+
+func explain(out io.Writer, cfg *config.Config, ignores []*ignore.Matcher, file string) error {
+	include, exclude, err := cfg.File.Explain(file)
+	if err != nil {
+		return err
+	}
+	ignoredBy, matched := explainIgnore(ignores, file)
+	binary := lib.IsBinary(file)
+	typ, by, err := config.DetectType(file)
+	if err != nil {
+		return err
+	}
+
+	linted := include != "" && exclude == "" && (ignoredBy == nil || ignoredBy.Negate()) && !binary
+	fmt.Fprintf(out, "file: %s\n", file)
+	fmt.Fprintf(out, "linted: %s\n", yesNo(linted))
+	if typ != "" {
+		fmt.Fprintf(out, "type: %s (by %s)\n", typ, by)
+	} else {
+		fmt.Fprintln(out, "type: unknown")
+	}
+	fmt.Fprintln(out)
+
+	if include != "" {
+		fmt.Fprintf(out, "  included: by %q in files.include\n", include)
+	} else {
+		fmt.Fprintln(out, "  included: no, no patterns in files.include match")
+	}
+	if exclude != "" {
+		fmt.Fprintf(out, "  excluded: by %q in files.exclude\n", exclude)
+	} else {
+		fmt.Fprintln(out, "  excluded: no")
+	}
+	switch {
+	case ignoredBy == nil:
+		fmt.Fprintln(out, "  ignored:  no")
+	case ignoredBy.Negate():
+		fmt.Fprintf(out, "  ignored:  no, re-included by %q at %s:%d\n", ignoredBy.String(), ignoredBy.Source, ignoredBy.Line)
+	default:
+		fmt.Fprintf(out, "  ignored:  by %q at %s:%d", ignoredBy.String(), ignoredBy.Source, ignoredBy.Line)
+		if matched != file {
+			fmt.Fprintf(out, " (matches %s)", matched)
+		}
+		fmt.Fprintln(out)
+	}
+	if binary {
+		fmt.Fprintln(out, "  binary:   yes, the content looks binary")
+	} else {
+		fmt.Fprintln(out, "  binary:   no")
+	}
+	fmt.Fprintln(out)
+
+	indexes, err := cfg.MatchedTargets(file, typ)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "targets:")
+	if len(indexes) == 0 {
+		fmt.Fprintln(out, "  (none)")
+	}
+	for _, i := range indexes {
+		t := cfg.Targets[i]
+		fmt.Fprintf(out, "  [%d] %s\n", i, t.Origin())
+		if len(t.Patterns) > 0 {
+			fmt.Fprintf(out, "      patterns: %s\n", strings.Join(t.Patterns, ", "))
+		}
+		if len(t.Types) > 0 {
+			fmt.Fprintf(out, "      types: %s\n", strings.Join(t.Types, ", "))
+		}
+		if len(t.ExcludePatterns) > 0 {
+			fmt.Fprintf(out, "      excludePatterns: %s\n", strings.Join(t.ExcludePatterns, ", "))
+		}
+	}
+	fmt.Fprintln(out)
+
+	rules, err := cfg.ExplainRules(file, typ)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "rules:")
+	configured := make(map[string]bool, len(rules))
+	for _, ro := range rules {
+		configured[ro.Rule] = true
+		if ro.Enforced() {
+			fmt.Fprintf(out, "  %s: enforced\n", ro.Rule)
+		} else {
+			fmt.Fprintf(out, "  %s: not enforced\n", ro.Rule)
+		}
+		for _, o := range ro.Options {
+			origin := o.Origin
+			if origin == "" {
+				origin = "(rule default)"
+			}
+			fmt.Fprintf(out, "    %s: %v  %s\n", o.Name, o.Value, origin)
+		}
+	}
+	for _, name := range lint.GetDefinedRules().GetAllRuleNames() {
+		if !configured[name] {
+			fmt.Fprintf(out, "  %s: not configured\n", name)
+		}
+	}
+
+	return nil
+}
+
+// explainIgnore returns the ignore pattern which decides whether the file is
+// ignored and the path it matched, which is relative like file.
+// The file is ignored if one of the ignore files ignores it, so a negated
+// pattern is returned only if no ignore files ignore the file.
+func explainIgnore(ignores []*ignore.Matcher, file string) (*ignore.Pattern, string) {
+	var (
+		negated *ignore.Pattern
+		matched string
+	)
+	for _, m := range ignores {
+		p, path := m.Explain(file, false)
+		if p == nil {
+			continue
+		}
+		if rel, err := relativePath(path); err == nil {
+			path = rel
+		}
+		if !p.Negate() {
+			return p, path
+		}
+		if negated == nil {
+			negated, matched = p, path
+		}
+	}
+	return negated, matched
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/synchro-food/filelint/config"
+	"github.com/synchro-food/filelint/dispatcher"
+	"github.com/synchro-food/filelint/lib"
+	"github.com/synchro-food/filelint/lint"
+)
+
+var initCmd = &cobra.Command{
+	Use:           "init",
+	Short:         "generate .filelint.yml inferred from the files in current directory",
+	RunE:          executeInit,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+var isAssumeYes bool
+
+func init() {
+	initCmd.Flags().BoolVarP(&isAssumeYes, "yes", "y", false, "use the inferred settings without asking")
+	commandRoot.AddCommand(initCmd)
+}
+
+func executeInit(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return Raise(usageErrorf("too many arguments: %s", strings.Join(args, " ")))
+	}
+
+	if lib.IsExist(config.FileName) {
+		return Raise(&ConfigError{Location: config.FileName, Err: errors.New("already exists")})
+	}
+
+	cfg, err := config.NewDefaultConfig()
+	if err != nil {
+		return Raise(err)
+	}
+
+	ignores, err := loadIgnores("", true)
+	if err != nil {
+		return Raise(err)
+	}
+
+	dp := dispatcher.NewDispatcher(cfg)
+	for _, m := range ignores {
+		dp.AddIgnore(m.Ignore)
+	}
+	var files []string
+	if err := dp.Dispatch(func(file, _ string, _ []lint.Rule, _ config.RuleMap) error {
+		files = append(files, file)
+		return nil
+	}); err != nil {
+		return Raise(err)
+	}
+
+	inf, err := config.Infer(files)
+	if err != nil {
+		return Raise(err)
+	}
+
+	if !isAssumeYes {
+		if err := askInference(os.Stdin, os.Stdout, inf); err != nil {
+			return Raise(&IOError{File: "stdin", Err: err})
+		}
+	}
+
+	if err := ioutil.WriteFile(config.FileName, inf.Generate(cfg.File), 0644); err != nil {
+		return Raise(err)
+	}
+	fmt.Printf("%s is generated from %d file(s)\n", config.FileName, inf.NumFiles)
+
+	return nil
+}
+
+// askInference asks the user to confirm or change each inferred setting.
+func askInference(in io.Reader, out io.Writer, inf *config.Inference) error {
+	p := &prompter{in: bufio.NewReader(in), out: out}
+
+	var err error
+	if inf.Linebreak, err = p.choose("Line endings", inf.Linebreak, "lf", "crlf"); err != nil {
+		return err
+	}
+	if inf.FinalNewline, err = p.number("Number of newlines at the end of files", inf.FinalNewline); err != nil {
+		return err
+	}
+	noBOM := "no"
+	if inf.NoBOM {
+		noBOM = "yes"
+	}
+	if noBOM, err = p.choose("Disallow BOM", noBOM, "yes", "no"); err != nil {
+		return err
+	}
+	inf.NoBOM = noBOM == "yes"
+	for _, ii := range inf.Indents {
+		if ii.Style, err = p.choose(fmt.Sprintf("Indentation of %s files", ii.Name), ii.Style, "tab", "space"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask prints the question and returns the answer, or def if the answer is empty.
+func (p *prompter) ask(question, def string) (string, error) {
+	fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	answer, err := p.in.ReadString('\n')
+	switch {
+	case err == io.EOF:
+		// the rest of questions are answered with the default values
+		fmt.Fprintln(p.out)
+	case err != nil:
+		return "", err
+	}
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+func (p *prompter) choose(question, def string, choices ...string) (string, error) {
+	q := fmt.Sprintf("%s (%s)", question, strings.Join(choices, "/"))
+	for {
+		answer, err := p.ask(q, def)
+		if err != nil {
+			return "", err
+		}
+		for _, c := range choices {
+			if strings.EqualFold(answer, c) {
+				return c, nil
+			}
+		}
+		fmt.Fprintf(p.out, "%q is not one of %s\n", answer, strings.Join(choices, ", "))
+	}
+}
+
+func (p *prompter) number(question string, def int) (int, error) {
+	for {
+		answer, err := p.ask(question, strconv.Itoa(def))
+		if err != nil {
+			return 0, err
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 0 {
+			return n, nil
+		}
+		fmt.Fprintf(p.out, "%q is not a non-negative number\n", answer)
+	}
+}
@@ -1,17 +1,22 @@
 package cli
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"runtime"
+	"runtime/debug"
 
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/synchro-food/filelint/baseline"
+	"github.com/synchro-food/filelint/cache"
 	"github.com/synchro-food/filelint/config"
 	"github.com/synchro-food/filelint/dispatcher"
+	"github.com/synchro-food/filelint/ignore"
 	"github.com/synchro-food/filelint/lib"
 	"github.com/synchro-food/filelint/lint"
 
@@ -21,36 +26,81 @@ import (
 const Version = "0.3.0"
 
 var rootCmd = &cobra.Command{
-	Use:           "filelint [files...]",
-	Short:         "lint any text file following some coding style",
-	Long:          `Filelint is a CLI tool for linting any text file following some coding style.`,
+	Use:   "filelint [files...]",
+	Short: "lint any text file following some coding style",
+	Long: `Filelint is a CLI tool for linting any text file following some coding style.
+
+Commands:
+  filelint explain <file>     print why the file is linted or not and the effective rule options
+  filelint init               generate .filelint.yml inferred from the files in current directory
+  filelint rules [rule-name]  print the documentation of the rules`,
 	RunE:          execute,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 }
 
+// commandRoot is the parent of the subcommands such as "filelint rules".
+// The subcommands are not added to rootCmd because cobra treats all arguments
+// of a command having subcommands as subcommand names, but the arguments of
+// rootCmd are lint target files.
+var commandRoot = &cobra.Command{
+	Use:           "filelint",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
 var (
 	configFile       string
 	userRules        []string
+	enableRules      []string
+	disableRules     []string
+	setOptions       []string
+	ruleSeverities   []string
+	onlyRules        []string
 	isShowVersion    bool
 	isPrintConfig    bool
+	printConfigFor   string
+	isPrintSchema    bool
 	isPrintTarget    bool
 	isAutofix        bool
+	isExitOnFix      bool
 	isQuiet          bool
 	useDefaultConfig bool
 	useGitIgnore     bool
+	useCache         bool
+	cacheLocation    string
+	ignorePath       string
+	isWriteBaseline  bool
+	baselineLocation string
 )
 
 func init() {
 	rootCmd.Flags().StringVarP(&configFile, "config", "c", "", "specify configuration file")
 	rootCmd.Flags().StringArrayVar(&userRules, "rule", []string{}, "specify rules")
+	rootCmd.Flags().StringArrayVar(&enableRules, "enable", []string{}, "enforce the rule, RULE[@GLOB]")
+	rootCmd.Flags().StringArrayVar(&disableRules, "disable", []string{}, "disable the rule, RULE[@GLOB]")
+	rootCmd.Flags().StringArrayVar(&setOptions, "set", []string{}, "set the option of the rule, RULE.OPTION=VALUE[@GLOB]")
+	rootCmd.Flags().StringArrayVar(&ruleSeverities, "severity", []string{}, "set the severity of the rule to error or warning, RULE=SEVERITY[@GLOB]")
+	rootCmd.Flags().StringArrayVar(&onlyRules, "only", []string{}, "run only the rule, RULE[@GLOB]")
 	rootCmd.Flags().BoolVarP(&isShowVersion, "version", "v", false, "print the version and quit")
 	rootCmd.Flags().BoolVar(&isPrintConfig, "print-config", false, "print the configuration")
+	rootCmd.Flags().StringVar(&printConfigFor, "for", "", "with --print-config, print the targets matching the file and its effective rules")
+	rootCmd.Flags().BoolVar(&isPrintSchema, "print-schema", false, "print the JSON Schema of the configuration file and quit")
 	rootCmd.Flags().BoolVar(&isPrintTarget, "print-targets", false, "print all lint target files and quit")
 	rootCmd.Flags().BoolVar(&isAutofix, "fix", false, "automatically fix problems")
+	rootCmd.Flags().BoolVar(&isExitOnFix, "exit-on-fix", false, "with --fix, exit with status 6 if any problems are fixed")
 	rootCmd.Flags().BoolVarP(&isQuiet, "quiet", "q", false, "don't print lint errors or fixed files")
 	rootCmd.Flags().BoolVar(&useDefaultConfig, "no-config", false, "don't use config file (use the application default config)")
-	rootCmd.Flags().BoolVar(&useGitIgnore, "use-gitignore", true, "(experimental) read and use .gitignore file for excluding target files")
+	rootCmd.Flags().BoolVar(&useGitIgnore, "use-gitignore", true, "read and use .gitignore files for excluding target files")
+	rootCmd.Flags().StringVar(&ignorePath, "ignore-path", "", "specify the file to use instead of .filelintignore files")
+	rootCmd.Flags().BoolVar(&useCache, "cache", false, "only check changed files")
+	rootCmd.Flags().StringVar(&cacheLocation, "cache-location", cache.DefaultLocation, "path to the cache file")
+	rootCmd.Flags().BoolVar(&isWriteBaseline, "write-baseline", false, "record the current lint errors to the baseline file, which are not reported later")
+	rootCmd.Flags().StringVar(&baselineLocation, "baseline-location", baseline.DefaultLocation, "path to the baseline file")
+
+	// the subcommands inherit it from commandRoot
+	rootCmd.SetFlagErrorFunc(flagError)
+	commandRoot.SetFlagErrorFunc(flagError)
 }
 
 var (
@@ -58,27 +108,52 @@ var (
 )
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		exitStatus := DefaultExitStatus
+	root := rootCmd
+	if len(os.Args) > 1 && isCommand(os.Args[1]) {
+		root = commandRoot
+	}
 
-		if ee, ok := err.(ExitError); ok {
-			exitStatus = ee.ExitStatus()
-		}
+	if cmd, err := executeC(root); err != nil {
+		exitStatus := Raise(err).ExitStatus()
 
 		switch exitStatus {
-		case LintFailedExitStatus:
+		case LintFailedExitStatus, FixedExitStatus:
 			break
-		case DefaultExitStatus:
+		case UsageExitStatus:
 			fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
-			rootCmd.Usage()
+			cmd.Usage()
 		default:
-			panic(err.Error())
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		}
 
 		os.Exit(exitStatus)
 	}
 }
 
+// executeC executes the command, and returns a panic as an internal error.
+func executeC(root *cobra.Command) (cmd *cobra.Command, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			cmd, err = root, &InternalError{Err: fmt.Errorf("%v\n%s", r, debug.Stack())}
+		}
+	}()
+	return root.ExecuteC()
+}
+
+// flagError returns the error of parsing flags, which prints the usage.
+func flagError(cmd *cobra.Command, err error) error {
+	return &UsageError{Err: err}
+}
+
+func isCommand(name string) bool {
+	for _, c := range commandRoot.Commands() {
+		if c.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
 func execute(cmd *cobra.Command, args []string) error {
 	var out io.Writer
 	if isQuiet {
@@ -92,58 +167,132 @@ func execute(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if isPrintSchema {
+		if err := printSchema(out); err != nil {
+			return Raise(err)
+		}
+		return nil
+	}
+
+	if printConfigFor != "" && !isPrintConfig {
+		return Raise(usageErrorf("--for must be used with --print-config"))
+	}
+
+	if isAutofix && isWriteBaseline {
+		return Raise(usageErrorf("--fix and --write-baseline can't be used together"))
+	}
+
+	if isExitOnFix && !isAutofix {
+		return Raise(usageErrorf("--exit-on-fix must be used with --fix"))
+	}
+
 	cfg, err := loadConfig(configFile, useDefaultConfig)
 	if err != nil {
 		return Raise(err)
 	}
 
-	if len(userRules) > 0 {
-		userRuleMap := make(config.RuleMap)
-		for _, r := range userRules {
-			if err := yaml.Unmarshal([]byte(r), &userRuleMap); err != nil {
-				return err
-			}
+	var c *cache.Cache
+	// all files are linted to write the baseline
+	if useCache && !isWriteBaseline {
+		// the cache is keyed by the loaded config, so it is invalidated
+		// whenever the config file is changed
+		c, err = cache.New(cacheLocation, Version, cfg)
+		if err != nil {
+			return Raise(&IOError{File: cacheLocation, Err: err})
+		}
+	}
+
+	for _, r := range userRules {
+		t, err := config.NewRuleTarget("--rule", []byte(r))
+		if err != nil {
+			return Raise(&UsageError{Err: err})
 		}
-		cfg.Targets = append(cfg.Targets, config.Target{
-			Patterns: []string{"**/*"},
-			Rule:     userRuleMap,
-		})
+		cfg.Targets = append(cfg.Targets, t)
+	}
+
+	flagTargets, err := newFlagTargets()
+	if err != nil {
+		return Raise(err)
 	}
+	cfg.Targets = append(cfg.Targets, flagTargets...)
 
 	if len(args) > 0 {
 		cfg.File.Include = args
 	}
 
 	if isPrintConfig {
-		if err := printConfig(out, cfg); err != nil {
+		if printConfigFor != "" {
+			err = printFileConfig(out, cfg, printConfigFor)
+		} else {
+			err = printConfig(out, cfg)
+		}
+		if err != nil {
 			return Raise(err)
 		}
 		return nil
 	}
 
+	ignores, err := loadIgnores(ignorePath, useGitIgnore)
+	if err != nil {
+		return Raise(err)
+	}
+
 	if isPrintTarget {
-		if err := printTarget(out, cfg.File); err != nil {
+		if err := printTarget(out, cfg, ignores); err != nil {
 			return Raise(err)
 		}
 		return nil
 	}
 
-	var gitignorePath string
-	if useGitIgnore {
-		var err error
-		gitignorePath, err = lib.FindGitIgnore()
-		if err != nil {
+	if isWriteBaseline {
+		if err := writeBaseline(out, cfg, ignores, baseline.New(baselineLocation)); err != nil {
 			return Raise(err)
 		}
+		return nil
 	}
 
-	if err := runLint(out, isAutofix, cfg, gitignorePath); err != nil {
+	b, err := baseline.Load(baselineLocation)
+	if err != nil {
+		return Raise(&IOError{Err: err})
+	}
+
+	if err := runLint(out, isAutofix, cfg, ignores, c, b); err != nil {
 		return Raise(err)
 	}
 
 	return nil
 }
 
+// newFlagTargets returns the targets of the flags changing the rules. The
+// flags are applied in the order of --set, --enable, --disable, --severity
+// and --only, regardless of the order in the command line.
+func newFlagTargets() ([]config.Target, error) {
+	var targets []config.Target
+	for _, f := range []struct {
+		flag   string
+		values []string
+	}{
+		{config.SetFlag, setOptions},
+		{config.EnableFlag, enableRules},
+		{config.DisableFlag, disableRules},
+		{config.SeverityFlag, ruleSeverities},
+		{config.OnlyFlag, onlyRules},
+	} {
+		if f.flag == config.OnlyFlag && len(f.values) > 0 {
+			targets = append(targets, config.OnlyTarget())
+		}
+		for _, v := range f.values {
+			t, err := config.NewFlagTarget(f.flag, v)
+			if err != nil {
+				return nil, &UsageError{Err: err}
+			}
+			targets = append(targets, t)
+		}
+	}
+
+	return targets, nil
+}
+
 func showVersion() {
 	fmt.Printf("filelint v%s [%s %s-%s]\n", Version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
 }
@@ -157,8 +306,55 @@ func printConfig(out io.Writer, cfg *config.Config) error {
 	return nil
 }
 
-func printTarget(out io.Writer, f config.File) error {
-	fs, err := f.FindTargets()
+type matchedTarget struct {
+	Index  int           `yaml:"index"`
+	Target config.Target `yaml:",inline"`
+}
+
+// printFileConfig prints the targets matching the file and the rules applied to it.
+func printFileConfig(out io.Writer, cfg *config.Config, file string) error {
+	typ, _, err := config.DetectType(file)
+	if err != nil {
+		return err
+	}
+	indexes, err := cfg.MatchedTargets(file, typ)
+	if err != nil {
+		return err
+	}
+	rules, err := cfg.MatchedRule(file, typ)
+	if err != nil {
+		return err
+	}
+
+	targets := make([]matchedTarget, 0, len(indexes))
+	for _, i := range indexes {
+		targets = append(targets, matchedTarget{Index: i, Target: cfg.Targets[i]})
+	}
+
+	yml, err := yaml.Marshal(&struct {
+		File    string          `yaml:"file"`
+		Targets []matchedTarget `yaml:"targets"`
+		Rules   config.RuleMap  `yaml:"rules"`
+	}{file, targets, rules})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s", yml)
+	return nil
+}
+
+func printSchema(out io.Writer) error {
+	src, err := json.MarshalIndent(config.NewSchema(), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s\n", src)
+	return nil
+}
+
+// printTarget prints the files which runLint lints.
+func printTarget(out io.Writer, cfg *config.Config, ignores []*ignore.Matcher) error {
+	fs, err := newDispatcher(cfg, ignores).Targets()
 	if err != nil {
 		return err
 	}
@@ -168,76 +364,298 @@ func printTarget(out io.Writer, f config.File) error {
 	return nil
 }
 
-func runLint(out io.Writer, isAutofix bool, cfg *config.Config, gitignorePath string) error {
-	dp := dispatcher.NewDispatcher(cfg)
+func runLint(out io.Writer, isAutofix bool, cfg *config.Config, ignores []*ignore.Matcher, c *cache.Cache, b *baseline.Baseline) error {
+	dp := newDispatcher(cfg, ignores)
+	paths := lint.LoadPaths(dp.Targets)
 
 	var (
-		numErrors      int
-		numFixedErrors int
-		numErrorFiles  int
-		numFixedFiles  int
+		numErrors       int
+		numWarnings     int
+		numFixedErrors  int
+		numErrorFiles   int
+		numWarningFiles int
+		numFixedFiles   int
 	)
 
-	if err := dp.Dispatch(gitignorePath, func(file string, rules []lint.Rule) error {
-		linter, err := lint.NewLinter(file, rules)
+	err := dp.Dispatch(func(file, typ string, rules []lint.Rule, matched config.RuleMap) error {
+		var (
+			entry *cache.Entry
+			err   error
+		)
+		if c != nil && isCacheable(rules) {
+			entry, err = cache.NewEntry(file, matched)
+			if err != nil {
+				return &IOError{File: file, Err: err}
+			}
+			if c.Has(file, entry) {
+				return nil
+			}
+		}
+
+		linter, err := newLinter(out, cfg, file, typ, rules, paths)
+		if err != nil || linter == nil {
+			return err
+		}
+		severities, err := matched.Severities()
 		if err != nil {
 			return err
 		}
+		linter.SetSeverities(severities)
+		fix := isAutofix && linter.CanFix()
 
 		result, err := linter.Lint()
 		if err != nil {
-			return err
+			return lintError(file, err)
 		}
 
-		if num := len(result.Reports); num > 0 {
-			numErrors += num
-			numErrorFiles++
-
+		// the reports which the rules can't fix remain after --fix
+		reports := result.Reports
+		var fixed []*lint.Report
+		if fix {
+			reports = make([]*lint.Report, 0, len(result.Reports))
 			for _, report := range result.Reports {
-				if isAutofix {
-					fmt.Fprintf(out, "[autofixed]")
-					numFixedErrors++
+				if report.Fixable() {
+					fixed = append(fixed, report)
+				} else {
+					reports = append(reports, report)
 				}
-				fmt.Fprintf(out, "%s:%s\n", file, report.String())
 			}
+		}
 
-			if isAutofix {
-				if err := writeFile(file, result.Fixed); err != nil {
-					return err
-				}
-				numFixedFiles++
+		unfixed := len(reports)
+
+		// the baselined errors which are fixed are pruned together, since
+		// they are no longer reported
+		if b != nil {
+			reports, err = b.Filter(file, linter.LintedRules(), reports)
+			if err != nil {
+				return &IOError{File: file, Err: err}
+			}
+		}
+
+		for _, report := range fixed {
+			fmt.Fprintf(out, "[autofixed]%s:%s\n", file, report.String())
+		}
+		numFixedErrors += len(fixed)
+
+		var hasErrors, hasWarnings bool
+		for _, report := range reports {
+			if report.Severity() == lint.SeverityWarning {
+				// warnings are reported but don't fail the lint
+				fmt.Fprintf(out, "[warning]")
+				numWarnings++
+				hasWarnings = true
+			} else {
+				numErrors++
+				hasErrors = true
+			}
+			fmt.Fprintf(out, "%s:%s\n", file, report.String())
+		}
+		if hasErrors {
+			numErrorFiles++
+		}
+		if hasWarnings {
+			numWarningFiles++
+		}
+
+		if len(fixed) > 0 {
+			if err := writeFile(file, result.Fixed); err != nil {
+				return &IOError{File: file, Err: err}
+			}
+			numFixedFiles++
+		}
+
+		if c != nil {
+			switch {
+			case entry == nil:
+				c.Delete(file)
+			case len(result.Reports) == 0:
+				c.Set(file, entry)
+			case len(fixed) > 0 && unfixed == 0:
+				c.Set(file, entry.WithContent(result.Fixed))
+			default:
+				c.Delete(file)
 			}
 		}
 
 		return nil
-	}); err != nil {
+	})
+	if c != nil {
+		if err := c.Save(); err != nil {
+			return &IOError{File: cacheLocation, Err: err}
+		}
+	}
+	if b != nil {
+		if err := b.Save(); err != nil {
+			return &IOError{File: baselineLocation, Err: err}
+		}
+	}
+	if err != nil {
 		return err
 	}
 
-	if !isAutofix && numErrors > 0 {
-		fmt.Fprintf(out, "%d lint error(s) detected in %d file(s)\n", numErrors, numErrorFiles)
-		return errLintFailed
+	if numWarnings > 0 {
+		fmt.Fprintf(out, "%d lint warning(s) detected in %d file(s)\n", numWarnings, numWarningFiles)
 	}
 
 	if numFixedFiles > 0 {
 		fmt.Fprintf(out, "%d lint error(s) autofixed in %d file(s)\n", numFixedErrors, numFixedFiles)
+	}
+
+	// the errors which can't be fixed fail the lint even with --fix
+	if numErrors > 0 {
+		fmt.Fprintf(out, "%d lint error(s) detected in %d file(s)\n", numErrors, numErrorFiles)
+		return errLintFailed
+	}
+
+	if numFixedFiles > 0 && isExitOnFix {
+		return errFixed
+	}
+
+	return nil
+}
+
+// isCacheable reports whether the result of the rules can be cached by the
+// file. The results of PathRules can depend on the other files such as the
+// paths colliding in case, which are not in the cache entry.
+func isCacheable(rules []lint.Rule) bool {
+	for _, rule := range rules {
+		if _, ok := rule.(lint.PathRule); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// newLinter returns the linter of file, or nil if the file is too large to lint.
+// paths is all the lint target files for the rules comparing paths.
+func newLinter(out io.Writer, cfg *config.Config, file, typ string, rules []lint.Rule, paths *lint.Paths) (*lint.Linter, error) {
+	fi, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.File.IsLarge(fi.Size()) {
+		linter, err := lint.NewLinter(file, typ, rules)
+		if err != nil {
+			return nil, err
+		}
+		linter.SetPaths(paths)
+		return linter, nil
+	}
+
+	// the large files rejected by their size are reported even if skipped
+	if linter, err := lint.NewStatLinter(file, rules); err != nil || linter != nil {
+		return linter, err
+	}
+
+	switch cfg.File.LargeFile {
+	case config.LargeFileStream:
+		linter := lint.NewStreamLinter(file, typ, rules)
+		linter.SetPaths(paths)
+		return linter, nil
+	case config.LargeFileSkip:
+		return nil, nil
+	default:
+		fmt.Fprintf(out, "[skipped]%s: file size %d bytes exceeds max-file-size %d bytes\n", file, fi.Size(), cfg.File.MaxFileSize)
+		return nil, nil
+	}
+}
+
+func writeBaseline(out io.Writer, cfg *config.Config, ignores []*ignore.Matcher, b *baseline.Baseline) error {
+	dp := newDispatcher(cfg, ignores)
+
+	paths := lint.LoadPaths(dp.Targets)
+
+	if err := dp.Dispatch(func(file, typ string, rules []lint.Rule, _ config.RuleMap) error {
+		linter, err := newLinter(out, cfg, file, typ, rules, paths)
+		if err != nil || linter == nil {
+			return err
+		}
+
+		result, err := linter.Lint()
+		if err != nil {
+			return lintError(file, err)
+		}
+
+		if err := b.Add(file, result.Reports); err != nil {
+			return &IOError{File: file, Err: err}
+		}
 		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := b.Save(); err != nil {
+		return &IOError{File: baselineLocation, Err: err}
 	}
 
+	fmt.Fprintf(out, "%d lint error(s) recorded in %s\n", b.Len(), baselineLocation)
 	return nil
 }
 
+// lintError returns the error of linting the file. The errors other than I/O
+// errors are the bugs of the rules.
+func lintError(file string, err error) error {
+	var pe *os.PathError
+	if errors.As(err, &pe) {
+		return &IOError{File: file, Err: err}
+	}
+	return &InternalError{Err: fmt.Errorf("%s: %v", file, err)}
+}
+
+// newDispatcher returns the dispatcher of the target files which skips the
+// files ignored by ignores.
+func newDispatcher(cfg *config.Config, ignores []*ignore.Matcher) *dispatcher.Dispatcher {
+	dp := dispatcher.NewDispatcher(cfg)
+	for _, m := range ignores {
+		dp.AddIgnore(m.Ignore)
+	}
+	return dp
+}
+
+func loadIgnores(ignorePath string, useGitIgnore bool) ([]*ignore.Matcher, error) {
+	var ignores []*ignore.Matcher
+
+	if useGitIgnore {
+		gi, err := ignore.FindGitIgnore()
+		if err != nil {
+			return nil, &IOError{File: ".gitignore", Err: err}
+		}
+		if gi != nil {
+			ignores = append(ignores, gi)
+		}
+	}
+
+	var (
+		fi  *ignore.Matcher
+		err error
+	)
+	if ignorePath != "" {
+		fi, err = ignore.NewIgnoreFile(ignorePath)
+	} else {
+		fi, err = ignore.FindFilelintIgnore()
+	}
+	if err != nil {
+		return nil, &IOError{File: ignorePath, Err: err}
+	}
+	ignores = append(ignores, fi)
+
+	return ignores, nil
+}
+
 func loadConfig(configFile string, useDefault bool) (*config.Config, error) {
 	if useDefault {
 		cfg, err := config.NewDefaultConfig()
 		if err != nil {
-			return nil, err
+			// the default config is embedded
+			return nil, &InternalError{Err: err}
 		}
 		return cfg, err
 	}
 
 	if configFile != "" && !lib.IsExist(configFile) {
-		return nil, ErrNoSuchConfigFile
+		return nil, &ConfigError{Location: configFile, Err: ErrNoSuchConfigFile}
 	}
 
 	if configFile == "" {
@@ -245,7 +663,7 @@ func loadConfig(configFile string, useDefault bool) (*config.Config, error) {
 		var err error
 		configFile, exist, err = config.SearchConfigFile()
 		if err != nil {
-			return nil, err
+			return nil, &IOError{Err: err}
 		}
 		if !exist {
 			return loadConfig("", true)
@@ -254,11 +672,21 @@ func loadConfig(configFile string, useDefault bool) (*config.Config, error) {
 
 	cfg, err := config.NewConfig(configFile)
 	if err != nil {
-		return nil, err
+		return nil, newConfigError(configFile, err)
 	}
 	return cfg, nil
 }
 
+// newConfigError returns the error of loading the config file. The validation
+// errors have the locations already.
+func newConfigError(configFile string, err error) error {
+	var ve config.ValidationErrors
+	if errors.As(err, &ve) {
+		return &ConfigError{Err: err}
+	}
+	return &ConfigError{Location: configFile, Err: err}
+}
+
 func writeFile(filename string, src []byte) error {
 	var fp *os.File
 	var err error
@@ -0,0 +1,366 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/synchro-food/filelint/config"
+	"github.com/synchro-food/filelint/lint"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:           "rules [rule-name]",
+	Short:         "print the documentation of the rules",
+	RunE:          executeRules,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+var rulesFormat string
+
+func init() {
+	rulesCmd.Flags().StringVar(&rulesFormat, "format", "text", "output format (text, json or markdown)")
+	commandRoot.AddCommand(rulesCmd)
+}
+
+type ruleDoc struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Fixable     bool          `json:"fixable"`
+	Rank        int           `json:"rank"`
+	Default     bool          `json:"default"`
+	Options     []*optionDoc  `json:"options"`
+	Examples    []*exampleDoc `json:"examples"`
+}
+
+type optionDoc struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Default     interface{} `json:"default"`
+	Enum        []string    `json:"enum,omitempty"`
+	Minimum     *int        `json:"minimum,omitempty"`
+	Maximum     *int        `json:"maximum,omitempty"`
+}
+
+type exampleDoc struct {
+	Options  map[string]interface{} `json:"options,omitempty"`
+	FileType string                 `json:"fileType,omitempty"`
+	Filename string                 `json:"filename,omitempty"`
+	Paths    []string               `json:"paths,omitempty"`
+	Source   string                 `json:"source"`
+	Reports  []string               `json:"reports"`
+	Fixed    string                 `json:"fixed"`
+}
+
+func executeRules(cmd *cobra.Command, args []string) error {
+	if err := printRules(os.Stdout, args, rulesFormat); err != nil {
+		return Raise(err)
+	}
+	return nil
+}
+
+func printRules(out io.Writer, args []string, format string) error {
+	if len(args) > 1 {
+		return usageErrorf("too many arguments: %s", strings.Join(args, " "))
+	}
+
+	defined := lint.GetDefinedRules()
+	names := defined.GetAllRuleNames()
+	if len(args) == 1 {
+		if !defined.Has(args[0]) {
+			return usageErrorf("%s is undefined", args[0])
+		}
+		names = []string{args[0]}
+	}
+
+	cfg, err := config.NewDefaultConfig()
+	if err != nil {
+		return err
+	}
+
+	var docs []*ruleDoc
+	for _, name := range names {
+		doc, err := newRuleDoc(defined.Get(name), cfg)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+	}
+
+	switch format {
+	case "text":
+		if len(args) == 1 {
+			printRuleDoc(out, docs[0])
+		} else {
+			printRuleList(out, docs)
+		}
+	case "json":
+		var v interface{} = docs
+		if len(args) == 1 {
+			v = docs[0]
+		}
+		src, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s\n", src)
+	case "markdown":
+		for i, doc := range docs {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			printRuleMarkdown(out, doc)
+		}
+	default:
+		return usageErrorf("unknown format %q (must be text, json or markdown)", format)
+	}
+
+	return nil
+}
+
+func newRuleDoc(r lint.Rule, cfg *config.Config) (*ruleDoc, error) {
+	md := r.MetaData()
+
+	// the rule is enabled by default if the default config enforces it for
+	// ordinary files
+	rules, err := cfg.MatchedRule("file", "")
+	if err != nil {
+		return nil, err
+	}
+	enforce, _ := rules[md.Name]["enforce"].(bool)
+
+	doc := &ruleDoc{
+		Name:        md.Name,
+		Description: md.Description,
+		Fixable:     md.Fixable,
+		Rank:        md.Rank(),
+		Default:     enforce,
+		Options:     []*optionDoc{},
+		Examples:    []*exampleDoc{},
+	}
+
+	for _, o := range md.Options {
+		doc.Options = append(doc.Options, &optionDoc{
+			Name:        o.Name,
+			Type:        string(o.Type),
+			Description: o.Description,
+			Default:     o.Default,
+			Enum:        o.Enum,
+			Minimum:     o.Min,
+			Maximum:     o.Max,
+		})
+	}
+
+	for _, ex := range md.Examples {
+		result, err := ex.Run(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid example: %v", md.Name, err)
+		}
+		exd := &exampleDoc{
+			Options:  ex.Options,
+			FileType: ex.FileType,
+			Filename: ex.Filename,
+			Paths:    ex.Paths,
+			Source:   ex.Source,
+			Reports:  []string{},
+			Fixed:    string(result.Fixed),
+		}
+		for _, report := range result.Reports {
+			exd.Reports = append(exd.Reports, report.String())
+		}
+		doc.Examples = append(doc.Examples, exd)
+	}
+
+	return doc, nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func printRuleList(out io.Writer, docs []*ruleDoc) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tFIXABLE\tDEFAULT\tDESCRIPTION")
+	for _, doc := range docs {
+		def := "-"
+		if doc.Default {
+			def = "enforce"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", doc.Name, yesNo(doc.Fixable), def, doc.Description)
+	}
+	w.Flush()
+}
+
+func printRuleDoc(out io.Writer, doc *ruleDoc) {
+	fmt.Fprintf(out, "%s\n\n", doc.Name)
+	fmt.Fprintf(out, "  %s\n\n", doc.Description)
+	fmt.Fprintf(out, "Fixable: %s\n", yesNo(doc.Fixable))
+	fmt.Fprintf(out, "Default: %s\n", enforceText(doc.Default))
+	fmt.Fprintf(out, "Rank:    %d (rules with lower rank are applied first)\n", doc.Rank)
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Options:")
+	if len(doc.Options) == 0 {
+		fmt.Fprintln(out, "  This rule has no options.")
+	}
+	for _, o := range doc.Options {
+		fmt.Fprintf(out, "  %s (%s)\n", o.Name, o.Type)
+		fmt.Fprintf(out, "      %s\n", o.Description)
+		fmt.Fprintf(out, "      default: %v\n", o.Default)
+		if s := optionValues(o, "%s"); s != "" {
+			fmt.Fprintf(out, "      available values: %s\n", s)
+		}
+	}
+
+	for i, ex := range doc.Examples {
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "Example %d:\n", i+1)
+		if len(ex.Options) > 0 {
+			fmt.Fprintf(out, "  options: %s\n", exampleOptions(ex.Options))
+		}
+		if ex.FileType != "" {
+			fmt.Fprintf(out, "  type:    %s\n", ex.FileType)
+		}
+		if ex.Filename != "" {
+			// the rules linting paths don't read the source
+			fmt.Fprintf(out, "  file:    %q\n", ex.Filename)
+			if len(ex.Paths) > 0 {
+				fmt.Fprintf(out, "  others:  %s\n", quoteAll(ex.Paths))
+			}
+		} else {
+			fmt.Fprintf(out, "  source:  %q\n", ex.Source)
+		}
+		fmt.Fprintln(out, "  reports:")
+		for _, r := range ex.Reports {
+			fmt.Fprintf(out, "    %s\n", r)
+		}
+		if ex.Filename == "" {
+			fmt.Fprintf(out, "  fixed:   %q\n", ex.Fixed)
+		}
+	}
+}
+
+func printRuleMarkdown(out io.Writer, doc *ruleDoc) {
+	fmt.Fprintf(out, "### `%s`\n\n", doc.Name)
+	fmt.Fprintf(out, "%s\n\n", doc.Description)
+	fmt.Fprintf(out, "- default: %s\n", enforceText(doc.Default))
+	fmt.Fprintf(out, "- fixable: %s\n", yesNo(doc.Fixable))
+	fmt.Fprintf(out, "- rank: %d\n", doc.Rank)
+
+	fmt.Fprintf(out, "\n#### Options\n\n")
+	if len(doc.Options) == 0 {
+		fmt.Fprintln(out, "This rule has no options.")
+	}
+	for i, o := range doc.Options {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "##### `%s`\n\n", o.Name)
+		fmt.Fprintf(out, "%s.\n\n", capitalize(o.Description))
+		fmt.Fprintf(out, "- type: %s\n", o.Type)
+		fmt.Fprintf(out, "- default: `%v`\n", o.Default)
+		if s := optionValues(o, "`%s`"); s != "" {
+			fmt.Fprintf(out, "- available values: %s\n", s)
+		}
+	}
+
+	if len(doc.Examples) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n#### Examples\n")
+	for _, ex := range doc.Examples {
+		fmt.Fprintln(out)
+		switch {
+		case len(ex.Options) > 0 && ex.FileType != "":
+			fmt.Fprintf(out, "With `%s` in a %s file:\n\n", exampleOptions(ex.Options), ex.FileType)
+		case len(ex.Options) > 0:
+			fmt.Fprintf(out, "With `%s`:\n\n", exampleOptions(ex.Options))
+		case ex.FileType != "":
+			fmt.Fprintf(out, "In a %s file:\n\n", ex.FileType)
+		}
+		fmt.Fprintln(out, "```")
+		if ex.Filename != "" {
+			fmt.Fprintf(out, "file:    %q\n", ex.Filename)
+			if len(ex.Paths) > 0 {
+				fmt.Fprintf(out, "others:  %s\n", quoteAll(ex.Paths))
+			}
+		} else {
+			fmt.Fprintf(out, "source:  %q\n", ex.Source)
+		}
+		for _, r := range ex.Reports {
+			fmt.Fprintf(out, "report:  %s\n", r)
+		}
+		if ex.Filename == "" {
+			fmt.Fprintf(out, "fixed:   %q\n", ex.Fixed)
+		}
+		fmt.Fprintln(out, "```")
+	}
+}
+
+func enforceText(b bool) string {
+	if b {
+		return "enforce"
+	}
+	return "not enforce"
+}
+
+func optionValues(o *optionDoc, format string) string {
+	switch {
+	case len(o.Enum) > 0:
+		var vs []string
+		for _, e := range o.Enum {
+			vs = append(vs, fmt.Sprintf(format, e))
+		}
+		return strings.Join(vs, ", ")
+	case o.Minimum != nil && o.Maximum != nil:
+		return fmt.Sprintf("integers from %d to %d", *o.Minimum, *o.Maximum)
+	case o.Minimum != nil:
+		return fmt.Sprintf("integers greater than or equal to %d", *o.Minimum)
+	case o.Maximum != nil:
+		return fmt.Sprintf("integers less than or equal to %d", *o.Maximum)
+	}
+	return ""
+}
+
+func quoteAll(ss []string) string {
+	quoted := make([]string, 0, len(ss))
+	for _, s := range ss {
+		quoted = append(quoted, fmt.Sprintf("%q", s))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func exampleOptions(ops map[string]interface{}) string {
+	var kvs []string
+	for _, k := range sortedKeys(ops) {
+		kvs = append(kvs, fmt.Sprintf("%s: %v", k, ops[k]))
+	}
+	return "{" + strings.Join(kvs, ", ") + "}"
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
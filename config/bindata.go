@@ -1,6 +1,14 @@
 // Code generated by go-bindata.
 // sources:
 // config/default.yml
+// config/presets/batch.yml
+// config/presets/go.yml
+// config/presets/json.yml
+// config/presets/makefile.yml
+// config/presets/markdown.yml
+// config/presets/python.yml
+// config/presets/toml.yml
+// config/presets/yaml.yml
 // DO NOT EDIT!
 
 package config
@@ -68,7 +76,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _configDefaultYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x8d\xc1\xaa\xc3\x20\x10\x45\xf7\x7e\xc5\xec\x84\x80\xc9\x7b\x5b\x7f\xa5\x74\x61\x92\x31\x48\xcd\x18\xc6\x91\xb6\x7f\x5f\x94\x34\x8b\x42\x5b\xea\xca\x7b\xae\xe7\xea\x43\xc4\x6c\x15\x40\xa0\x29\x96\x19\xeb\x15\xc0\x80\xee\x87\xae\x1b\x3a\xad\x00\xf0\xf6\xd2\x2c\x41\x8e\xb2\x91\x1a\xfa\x6d\xf6\x5a\x29\x71\xbc\xa0\xb4\x45\x03\x9b\x13\x41\xa6\x6c\xe1\xd4\xde\xe8\x73\x33\xb8\xec\x7f\xd6\x13\x03\xe1\xc8\xe8\x2e\x4f\x00\x80\xe4\x13\x4f\x68\x41\xb8\xe0\x41\xb3\xdc\x23\x5a\x88\x7e\x27\x3e\x70\x16\x43\x78\xad\x0b\xdf\x64\x2a\xab\x85\xbf\xc3\x24\x17\x7f\x32\xff\xf7\x48\xc9\x8c\x69\xfd\xac\x50\x32\x98\xa2\xc9\x9b\x9b\xde\x8e\x3f\x02\x00\x00\xff\xff\x65\xc4\x4b\x35\x76\x01\x00\x00")
+var _configDefaultYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x52\xbb\x6e\xc3\x30\x0c\xdc\xfd\x15\x04\x3a\x04\x08\x20\xa7\x5d\xfd\x2b\x45\x07\xda\xa6\x12\x35\xb2\x64\x88\x34\x9c\xf4\xeb\x4b\x49\x49\x86\x34\x7d\x79\x12\x8f\xba\xe3\xe9\x68\xeb\x3c\x71\xd7\x00\xb8\x30\xf8\x65\xa4\x7c\x04\x30\xb0\x69\x77\xdb\xed\x6e\xbb\xd1\x92\x4e\x77\x9d\xbd\x93\x5b\xb3\x20\xb9\x68\xe7\xd1\xde\x80\xd6\xaa\xac\x77\x41\x06\x1c\x0e\xf4\x15\x36\x3d\x72\x3e\x50\xfb\xce\x31\xe4\xfe\x84\x27\x93\xbb\x86\xdd\x07\x75\xf0\xac\x90\xc7\xb4\xa7\x02\x76\xb0\x62\x0a\x4d\x23\x19\x91\x62\xd7\xc0\x8c\x22\x94\x02\x77\xf0\x5a\x0c\x6c\xde\xca\x98\xb4\x5c\x1e\x94\xbf\x3c\xa2\x4f\x84\xc7\x2b\xa0\xaf\x09\x36\xa6\x41\x25\x25\x2d\x74\x43\x59\xce\x79\x8c\xb7\x17\xc4\xba\xc4\x62\x02\xad\x59\xe1\x37\x72\x58\xa6\xea\xb8\x32\x03\xfa\x7f\x31\x5f\x2e\x65\x88\xa6\x8f\xd3\xcf\x14\xbd\x43\xd1\x1b\x9e\x71\xf8\x56\xbc\x51\xfc\x09\x12\x0d\x6e\x26\x86\x68\x35\xdb\x23\xe5\x18\x19\xa6\x85\x05\x7a\xd2\x65\x8f\x14\x84\x46\x58\x9d\x1c\x40\xb0\xe7\x12\xa9\x9c\x95\xa1\x79\x5e\x09\x0f\x12\xad\xcc\x3f\xc6\xa9\xc2\xd5\x8c\xac\x51\x2f\xa0\xd3\x4c\xf6\x50\xcc\x33\x60\xa2\xb2\x1f\x28\x0b\x62\x55\x56\xa3\xe9\x38\xc6\x35\xdc\x99\xa9\xe0\x03\x33\x8f\xd3\x40\xef\xe3\x6a\xae\x34\x73\xc0\x34\x9a\xfa\x13\x54\x93\x9f\x6a\xe3\x12\xa3\xf3\x02\x00\x00")
 
 func configDefaultYmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -83,7 +91,167 @@ func configDefaultYml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "config/default.yml", size: 374, mode: os.FileMode(420), modTime: time.Unix(1530522802, 0)}
+	info := bindataFileInfo{name: "config/default.yml", size: 755, mode: os.FileMode(420), modTime: time.Unix(1792375343, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configPresetsBatchYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3d\x8e\xbd\x0a\xc2\x30\x14\x85\xf7\x3e\xc5\x81\x0e\x85\x52\xeb\x9e\x4d\x2b\x4e\xba\x88\xe0\x20\x0e\x69\x72\x5b\x83\xe9\xad\xe4\xa6\xa8\x88\xef\x6e\x28\xe8\x76\xf8\x38\x7f\x39\x4e\x8e\xed\xf8\x10\xb4\x3a\x9a\x2b\x3a\xe7\x49\xc0\x44\x16\xcd\x61\xb7\x85\x77\x4c\x20\xb6\x8e\x7b\xa9\x20\x8e\x0d\xc1\x0c\xb6\xa6\x27\x61\x70\x12\x48\x5b\x81\xd7\x2d\x79\xc9\x72\x68\xb6\xe8\xc7\x38\x0a\x1c\x23\xa5\xe7\xb6\x2c\xea\xd0\x53\x14\x95\x01\x0b\xdc\x75\x8c\x14\x58\x14\xce\x45\x59\x2e\xcb\xfa\x9d\x86\xab\x54\x59\xad\x57\xc7\xaa\xd9\x6f\x3e\xc5\x25\x19\x81\x30\xa5\xac\x9a\x25\xe6\x1b\x6d\x1a\xbb\xfd\x00\xd2\xa9\x6e\x0c\x86\x14\x62\x98\xe8\x4f\x25\xbe\x7c\x62\x26\xf8\x2e\xfb\x02\x92\x0a\x7a\xb3\xdc\x00\x00\x00")
+
+func configPresetsBatchYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_configPresetsBatchYml,
+		"config/presets/batch.yml",
+	)
+}
+
+func configPresetsBatchYml() (*asset, error) {
+	bytes, err := configPresetsBatchYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/presets/batch.yml", size: 220, mode: os.FileMode(420), modTime: time.Unix(1792373089, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configPresetsGoYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x45\xcb\x31\x0e\xc3\x20\x14\x03\xd0\x9d\x53\x58\xea\xdc\x3d\xe2\x2a\x55\x06\xd2\x7c\x28\x12\x85\x8a\xef\x28\x22\xa7\x2f\x49\xd4\xc6\x9b\x9f\xe5\x1b\x42\xf1\x6f\x22\xe6\x59\x32\x15\x6b\xe4\x0b\x74\x93\x1a\xba\x1a\x84\x6a\x0d\x70\x07\xdb\x47\xd4\xe2\x11\xca\xd8\x3b\x50\x97\x24\xc7\xb4\xe7\xfc\xfe\x1a\x20\xd9\x97\xfa\x14\x0b\xd6\x45\xfe\xaa\x6c\x69\x37\x37\x5d\x14\xb7\x2e\x83\xf9\x02\x66\xda\x6e\xd9\x85\x00\x00\x00")
+
+func configPresetsGoYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_configPresetsGoYml,
+		"config/presets/go.yml",
+	)
+}
+
+func configPresetsGoYml() (*asset, error) {
+	bytes, err := configPresetsGoYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/presets/go.yml", size: 133, mode: os.FileMode(420), modTime: time.Unix(1792371075, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configPresetsJsonYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x2b\x49\x2c\x4a\x4f\x2d\x29\xb6\xe2\x52\x50\xd0\x55\x28\xa9\x2c\x48\x2d\xb6\x52\x88\xce\x2a\xce\xcf\x8b\x05\x8a\x28\x28\x14\x95\xe6\xa4\x82\x25\x41\xa0\x2c\x31\x27\x33\x45\x17\x24\x09\x13\x51\x50\x48\xcd\x4b\xcb\x2f\x4a\x4e\xb5\x52\x28\x29\x2a\x4d\xe5\x02\x00\xe0\x41\xe7\xdd\x4e\x00\x00\x00")
+
+func configPresetsJsonYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_configPresetsJsonYml,
+		"config/presets/json.yml",
+	)
+}
+
+func configPresetsJsonYml() (*asset, error) {
+	bytes, err := configPresetsJsonYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/presets/json.yml", size: 78, mode: os.FileMode(420), modTime: time.Unix(1792371078, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configPresetsMakefileYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x45\xcc\xb1\x0e\xc2\x30\x10\x03\xd0\x3d\x5f\x61\x89\x99\x1d\xe5\x57\x10\x43\xd2\x3a\xf4\x44\x9b\xa2\xcb\x45\xa8\x7c\x3d\x07\xa2\xb0\xd9\x4f\xb2\x0f\x50\x0e\x72\x67\xc3\x5a\xb0\xa4\x1b\x8b\xcc\x5e\x96\xde\x0c\x99\x90\x3a\xb2\x1a\x47\x3c\xc4\x26\x58\xca\x2d\x58\xd2\x2b\xad\xc5\x00\x1c\x61\x9b\x4f\x23\xce\xfb\xf2\xe2\x0a\x68\xf7\x8f\xf8\x89\xf8\x5e\xec\x0d\x60\x2d\xab\x0e\x8c\x30\xed\xfc\x69\xb3\x6d\x7e\x5b\xca\x7f\x92\xa7\xcb\x29\xbc\x00\x4a\xce\x21\xd1\xa3\x00\x00\x00")
+
+func configPresetsMakefileYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_configPresetsMakefileYml,
+		"config/presets/makefile.yml",
+	)
+}
+
+func configPresetsMakefileYml() (*asset, error) {
+	bytes, err := configPresetsMakefileYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/presets/makefile.yml", size: 163, mode: os.FileMode(420), modTime: time.Unix(1792371075, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configPresetsMarkdownYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x8f\x41\x0a\xc2\x40\x0c\x45\xf7\x9e\xe2\x83\xeb\xb9\x40\xaf\x22\x2e\x62\x1b\x75\x68\x9c\x29\x49\x4a\xe9\xed\x8d\x43\x8b\x20\x6a\x36\x21\xc9\xff\xef\x13\x27\xbd\xb1\x5b\x77\x00\x12\x7c\x9d\xd8\x3a\x9c\x1e\xa4\xe3\x50\x97\x72\x8e\x2d\xa0\xb3\x70\x13\xbc\xea\x08\x5f\x2a\x5c\x29\x4b\x2e\x37\xd8\x44\x3d\x1b\x48\x19\x31\x33\x2e\xca\x34\x1a\x72\xc1\xce\xd8\x7c\xa5\x26\xae\x92\x9a\x7e\x67\x01\x24\x52\x97\xb4\x4b\xd3\x9d\x74\x48\x0d\xd1\x45\xc4\xcc\x9b\xee\x7d\x67\x1a\x22\xb5\x51\xa2\xbf\x39\x5c\xae\x55\x03\xfc\xd5\x25\xd9\xbc\x65\xb0\xfe\x77\x00\xe6\xab\xc4\xae\xaf\xc5\xc2\xc4\xc5\x3f\x59\xf1\xc6\x63\x16\xcf\x93\x70\xba\x08\x95\x31\xbd\xde\xb6\x5f\xdc\x27\x5c\x4e\x62\xf4\x5e\x01\x00\x00")
+
+func configPresetsMarkdownYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_configPresetsMarkdownYml,
+		"config/presets/markdown.yml",
+	)
+}
+
+func configPresetsMarkdownYml() (*asset, error) {
+	bytes, err := configPresetsMarkdownYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/presets/markdown.yml", size: 350, mode: os.FileMode(420), modTime: time.Unix(1792371075, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configPresetsPythonYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x45\xcd\xb1\x0a\x80\x30\x0c\x04\xd0\xbd\x5f\x71\xe0\xec\xe6\x20\xdd\xdd\xdd\xc5\x41\x34\x6a\xa1\xb4\xa5\x89\x48\xfd\x7a\xab\xa2\x66\xbb\x77\x70\x29\xd0\x36\x2d\x6a\x18\x37\x91\x13\xc6\x6e\x64\x45\x05\x0e\xc3\x48\xac\x64\x88\x0b\x09\x6b\x05\x94\x90\x14\x88\x35\xba\x90\x64\xf5\xae\xcf\x06\xc4\xcd\xd2\x5d\x5f\xf7\x6c\xbc\x09\x20\x37\xfb\x38\x92\x86\xc4\x8d\x3e\x65\x49\x36\xdb\xfd\xe1\x47\x73\x64\xab\xd4\x09\x95\x07\x42\x25\x8f\x00\x00\x00")
+
+func configPresetsPythonYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_configPresetsPythonYml,
+		"config/presets/python.yml",
+	)
+}
+
+func configPresetsPythonYml() (*asset, error) {
+	bytes, err := configPresetsPythonYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/presets/python.yml", size: 143, mode: os.FileMode(420), modTime: time.Unix(1792371075, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configPresetsTomlYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x2b\x49\x2c\x4a\x4f\x2d\x29\xb6\xe2\x52\x50\xd0\x55\x28\xa9\x2c\x48\x2d\xb6\x52\x88\x2e\xc9\xcf\xcd\x89\x05\x8a\x28\x28\x14\x95\xe6\xa4\x82\x25\x41\xa0\x2c\x31\x27\x33\x45\x17\x24\x09\x13\x51\x50\x48\xcd\x4b\xcb\x2f\x4a\x4e\xb5\x52\x28\x29\x2a\x4d\xe5\x02\x00\xde\x8c\xd4\xae\x4e\x00\x00\x00")
+
+func configPresetsTomlYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_configPresetsTomlYml,
+		"config/presets/toml.yml",
+	)
+}
+
+func configPresetsTomlYml() (*asset, error) {
+	bytes, err := configPresetsTomlYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/presets/toml.yml", size: 78, mode: os.FileMode(420), modTime: time.Unix(1792371075, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _configPresetsYamlYml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x8d\xbd\x0e\xc2\x30\x0c\x84\xf7\x3e\xc5\x49\xcc\x5d\x18\xb3\xb1\xc3\x03\x20\xc4\x60\x1a\x83\x22\x19\xa7\x8a\x5d\x50\xfa\xf4\xa4\xfc\x89\x05\x4f\x77\xdf\xc9\x77\x2b\x38\x9d\x0c\x54\x18\x9a\x1d\x24\x92\xef\x1c\x71\xce\x05\x49\x23\xab\x93\xa7\xac\x4d\x63\xbf\xd9\x6d\x3b\xa7\x72\x61\xb7\xd0\x01\x3d\xbc\x8e\x6c\x01\x87\x4a\x57\x39\x36\x02\x94\x49\xf8\x19\x2e\xf7\xfa\xff\x38\x80\xb5\xb5\x0e\x1c\xe0\x65\xe2\x2f\x35\xaf\xd2\x98\x8d\x34\xfc\xc0\x34\x37\xb6\x7e\xfb\x1b\x49\x8a\xfd\xb2\xf2\xaf\xec\x01\x75\x41\x4f\xa5\xc6\x00\x00\x00")
+
+func configPresetsYamlYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_configPresetsYamlYml,
+		"config/presets/yaml.yml",
+	)
+}
+
+func configPresetsYamlYml() (*asset, error) {
+	bytes, err := configPresetsYamlYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/presets/yaml.yml", size: 198, mode: os.FileMode(420), modTime: time.Unix(1792371075, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -141,6 +309,14 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"config/default.yml": configDefaultYml,
+	"config/presets/batch.yml": configPresetsBatchYml,
+	"config/presets/go.yml": configPresetsGoYml,
+	"config/presets/json.yml": configPresetsJsonYml,
+	"config/presets/makefile.yml": configPresetsMakefileYml,
+	"config/presets/markdown.yml": configPresetsMarkdownYml,
+	"config/presets/python.yml": configPresetsPythonYml,
+	"config/presets/toml.yml": configPresetsTomlYml,
+	"config/presets/yaml.yml": configPresetsYamlYml,
 }
 
 // AssetDir returns the file names below a certain
@@ -185,6 +361,16 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"config": &bintree{nil, map[string]*bintree{
 		"default.yml": &bintree{configDefaultYml, map[string]*bintree{}},
+		"presets": &bintree{nil, map[string]*bintree{
+			"batch.yml": &bintree{configPresetsBatchYml, map[string]*bintree{}},
+			"go.yml": &bintree{configPresetsGoYml, map[string]*bintree{}},
+			"json.yml": &bintree{configPresetsJsonYml, map[string]*bintree{}},
+			"makefile.yml": &bintree{configPresetsMakefileYml, map[string]*bintree{}},
+			"markdown.yml": &bintree{configPresetsMarkdownYml, map[string]*bintree{}},
+			"python.yml": &bintree{configPresetsPythonYml, map[string]*bintree{}},
+			"toml.yml": &bintree{configPresetsTomlYml, map[string]*bintree{}},
+			"yaml.yml": &bintree{configPresetsYamlYml, map[string]*bintree{}},
+		}},
 	}},
 }}
 
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -9,15 +10,20 @@ import (
 
 	yaml "gopkg.in/yaml.v2"
 
-	"github.com/fatih/set"
-	zglob "github.com/mattn/go-zglob"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/mohae/deepcopy"
+	"github.com/synchro-food/filelint/filetype"
 	"github.com/synchro-food/filelint/lib"
+	"github.com/synchro-food/filelint/lint"
 )
 
 type Config struct {
-	File    File     `yaml:"files"`
+	File File `yaml:"files"`
+
+	// Use is the names of the built-in presets, which are expanded into
+	// Targets when the config is loaded
+	Use []string `yaml:"use,omitempty"`
+
 	Targets []Target `yaml:"targets"`
 }
 
@@ -33,10 +39,18 @@ func NewConfig(configFile string) (*Config, error) {
 		return nil, err
 	}
 
+	if err := Validate(configFile, src); err != nil {
+		return nil, err
+	}
+
 	if err := yaml.Unmarshal(src, &userConfig); err != nil {
 		return nil, err
 	}
+	setOrigins(userConfig.Targets, configFile, src)
 
+	if err := userConfig.expandPresets(); err != nil {
+		return nil, err
+	}
 	conf.Merge(userConfig)
 
 	return conf, nil
@@ -52,6 +66,7 @@ func NewDefaultConfig() (*Config, error) {
 	if err := yaml.Unmarshal(src, &conf); err != nil {
 		return nil, err
 	}
+	setOrigins(conf.Targets, DefaultConfigName, src)
 
 	return conf, nil
 }
@@ -61,82 +76,161 @@ func (src *Config) Merge(dst *Config) {
 		src.File.Include = dst.File.Include
 	}
 	src.File.Exclude = append(src.File.Exclude, dst.File.Exclude...)
+	if dst.File.MaxFileSize != 0 {
+		src.File.MaxFileSize = dst.File.MaxFileSize
+	}
+	if dst.File.LargeFile != "" {
+		src.File.LargeFile = dst.File.LargeFile
+	}
 	src.Targets = append(src.Targets, dst.Targets...)
 }
 
-func (cfg *Config) MatchedRule(file string) RuleMap {
+// DetectType returns the type of the file which the types of targets match,
+// and how it is detected. The type is detected once for each file and passed
+// to the matching methods, since it may read the file. DetectType is replaced
+// in tests to count the detections.
+var DetectType = filetype.Detect
+
+// MatchedRule returns the rules applied to the file of the type.
+func (cfg *Config) MatchedRule(file, typ string) (RuleMap, error) {
 	rm := make(RuleMap)
 
-	for _, t := range cfg.Targets {
-		if match(file, t.Patterns) {
-			rm = rm.Merge(t.Rule)
+	indexes, err := cfg.MatchedTargets(file, typ)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range indexes {
+		rm = rm.Merge(cfg.Targets[i].Rule)
+	}
+
+	return rm, nil
+}
+
+// MatchedTargets returns the indexes of the targets which match the file of
+// the type.
+func (cfg *Config) MatchedTargets(file, typ string) ([]int, error) {
+	var indexes []int
+
+	for i, t := range cfg.Targets {
+		ok, err := t.Match(file, typ)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			indexes = append(indexes, i)
 		}
 	}
 
-	return rm
+	return indexes, nil
 }
 
-func match(file string, patterns []string) bool {
+// match reports whether the file matches the patterns.
+// A pattern starting with "!" excludes the files matched by the preceding
+// patterns. If the first pattern is negated, the patterns match all files
+// except the negated ones.
+func match(file string, patterns []string) (bool, error) {
 	if len(patterns) == 0 {
-		return true
+		return true, nil
 	}
 
+	matched := strings.HasPrefix(patterns[0], "!")
 	for _, pattern := range patterns {
-		ok, err := zglob.Match(pattern, file)
+		negate := strings.HasPrefix(pattern, "!")
+		g, err := CompileGlob(strings.TrimPrefix(pattern, "!"))
 		if err != nil {
-			return false
+			return false, err
 		}
-		if ok {
-			return true
+		if g.Match(file) {
+			matched = !negate
 		}
 	}
 
-	return false
+	return matched, nil
 }
 
 type File struct {
 	Include []string `yaml:"include"`
 	Exclude []string `yaml:"exclude"`
+
+	// MaxFileSize is the size limit of lint target files, 0 means unlimited.
+	MaxFileSize Size `yaml:"max-file-size,omitempty"`
+
+	// LargeFile specifies how to handle the files larger than MaxFileSize.
+	LargeFile LargeFileAction `yaml:"large-file,omitempty"`
 }
 
-func (f File) FindTargets() ([]string, error) {
-	f.Include = addGlobSignIfDir(f.Include...)
-	f.Exclude = addGlobSignIfDir(f.Exclude...)
+type LargeFileAction string
 
-	inSet, err := expandGlob(f.Include)
-	if err != nil {
-		return nil, err
-	}
+const (
+	// skip large files silently
+	LargeFileSkip LargeFileAction = "skip"
 
-	exSet, _ := expandGlob(f.Exclude)
-	if err != nil {
-		return nil, err
+	// skip large files with a warning
+	LargeFileWarn LargeFileAction = "warn"
+
+	// lint large files line by line with the rules supporting it
+	LargeFileStream LargeFileAction = "stream"
+)
+
+func (a *LargeFileAction) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
 	}
 
-	fileSet := set.Difference(inSet, exSet)
-	files := set.StringSlice(fileSet)
-	files = lib.FindTextFiles(files)
+	switch action := LargeFileAction(s); action {
+	case LargeFileSkip, LargeFileWarn, LargeFileStream:
+		*a = action
+	default:
+		return fmt.Errorf("files.large-file is invalid: %q (available: skip, warn, stream)", s)
+	}
 
-	sort.Strings(files)
+	return nil
+}
 
-	return files, nil
+// IsLarge reports whether a file of the size exceeds MaxFileSize.
+func (f File) IsLarge(size int64) bool {
+	return f.MaxFileSize > 0 && size > int64(f.MaxFileSize)
 }
 
-func expandGlob(files []string) (*set.Set, error) {
-	fileSet := set.New()
+// Size is a number of bytes. It can be written as "512", "100KB", "10MB" or "1GB".
+type Size int64
 
-	for _, f := range files {
-		expanded, err := zglob.Glob(f)
-		if err != nil {
-			return nil, err
-		}
+func (s *Size) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n int64
+	if err := unmarshal(&n); err == nil {
+		*s = Size(n)
+		return nil
+	}
 
-		for _, ex := range expanded {
-			fileSet.Add(ex)
-		}
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
 	}
 
-	return fileSet, nil
+	n, err := lib.ParseSize(str)
+	if err != nil {
+		return err
+	}
+	*s = Size(n)
+
+	return nil
+}
+
+// FindTargets returns the sorted lint target files. ignore may be nil.
+func (f File) FindTargets(ignore IgnoreFunc) ([]string, error) {
+	files := []string{}
+
+	if err := f.Walk(ignore, func(path string) error {
+		files = append(files, path)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+
+	return files, nil
 }
 
 func addGlobSignIfDir(files ...string) (dst []string) {
@@ -149,6 +243,9 @@ func addGlobSignIfDir(files ...string) (dst []string) {
 				continue
 			}
 
+			// the existing path is matched literally even if it has glob
+			// meta characters such as `[`
+			f = EscapeGlob(f)
 			if info.IsDir() {
 				f = filepath.Join(f, "**", "*")
 			}
@@ -161,8 +258,42 @@ func addGlobSignIfDir(files ...string) (dst []string) {
 }
 
 type Target struct {
-	Patterns []string `yaml:"patterns"`
-	Rule     RuleMap  `yaml:"rules"`
+	Patterns        []string `yaml:"patterns,omitempty"`
+	ExcludePatterns []string `yaml:"excludePatterns,omitempty"`
+
+	// Types is the file types detected by package filetype such as "shell",
+	// and the target matches all types if it is empty
+	Types []string `yaml:"types,omitempty"`
+
+	Rule RuleMap `yaml:"rules"`
+
+	origin *origin
+}
+
+// Match reports whether the rules of the target are applied to the file of
+// the type, which is empty if the type is unknown.
+func (t Target) Match(file, typ string) (bool, error) {
+	ok, err := match(file, t.Patterns)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	if len(t.ExcludePatterns) > 0 {
+		excluded, err := match(file, t.ExcludePatterns)
+		if err != nil || excluded {
+			return false, err
+		}
+	}
+
+	if len(t.Types) == 0 {
+		return true, nil
+	}
+	for _, tt := range t.Types {
+		if tt == typ {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 type RuleMap map[string]map[string]interface{}
@@ -183,8 +314,30 @@ func (rm RuleMap) Merge(dst RuleMap) (ret RuleMap) {
 	return ret
 }
 
+// Severities returns the severities of the rules which have the severity
+// option. The value which is not one of the enum is an error, though the
+// schema rejects it when the config is loaded.
+func (rm RuleMap) Severities() (map[string]lint.Severity, error) {
+	ret := make(map[string]lint.Severity)
+	for ruleName, options := range rm {
+		v, ok := options[lint.SeverityOption.Name]
+		if !ok {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok || !inEnum(lint.SeverityOption.Enum, s) {
+			return nil, fmt.Errorf("%s.%s: must be one of %s but %#v", ruleName, lint.SeverityOption.Name, strings.Join(lint.SeverityOption.Enum, ", "), v)
+		}
+		ret[ruleName] = lint.Severity(s)
+	}
+	return ret, nil
+}
+
+// FileName is the name of the config file.
+const FileName = ".filelint.yml"
+
 var (
-	fileName   = ".filelint.yml"
+	fileName   = FileName
 	searchPath = "."
 )
 
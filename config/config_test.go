@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
 )
 
 func TestConfig_Merge(t *testing.T) {
@@ -42,6 +43,16 @@ func TestConfig_Merge(t *testing.T) {
 			dst:  &Config{File: File{Exclude: []string{}}},
 			want: &Config{File: File{Exclude: []string{"a"}}},
 		},
+		{
+			src:  &Config{File: File{MaxFileSize: 1, LargeFile: LargeFileWarn}},
+			dst:  &Config{File: File{MaxFileSize: 2, LargeFile: LargeFileStream}},
+			want: &Config{File: File{MaxFileSize: 2, LargeFile: LargeFileStream}},
+		},
+		{
+			src:  &Config{File: File{MaxFileSize: 1, LargeFile: LargeFileWarn}},
+			dst:  &Config{File: File{}},
+			want: &Config{File: File{MaxFileSize: 1, LargeFile: LargeFileWarn}},
+		},
 		{
 			src: &Config{Targets: []Target{Target{
 				Patterns: []string{"*"},
@@ -146,15 +157,126 @@ func TestConfig_MatchedRule(t *testing.T) {
 			file: "path/to/a.go",
 			want: RuleMap{"a": {"A": 3}},
 		},
+		{
+			src: []Target{
+				{
+					Patterns:        []string{"**/*"},
+					ExcludePatterns: []string{"**/*.md"},
+					Rule:            RuleMap{"a": {"A": 1}},
+				},
+			},
+			file: "path/to/a.md",
+			want: RuleMap{},
+		},
+		{
+			src: []Target{
+				{
+					Patterns: []string{"**/*", "!**/*.md"},
+					Rule:     RuleMap{"a": {"A": 1}},
+				},
+			},
+			file: "path/to/a.md",
+			want: RuleMap{},
+		},
+		{
+			src: []Target{
+				{
+					Patterns: []string{"!**/*.md"},
+					Rule:     RuleMap{"a": {"A": 1}},
+				},
+			},
+			file: "path/to/a.go",
+			want: RuleMap{"a": {"A": 1}},
+		},
+		{
+			src: []Target{
+				{
+					Patterns: []string{"**/*.md", "!docs/**", "docs/README.md"},
+					Rule:     RuleMap{"a": {"A": 1}},
+				},
+			},
+			file: "docs/README.md",
+			want: RuleMap{"a": {"A": 1}},
+		},
+		{
+			src: []Target{
+				{
+					Patterns: []string{"**/*"},
+					Rule:     RuleMap{"indent": {"style": "space"}},
+				},
+				{
+					Types: []string{"makefile"},
+					Rule:  RuleMap{"indent": {"style": "tab"}},
+				},
+			},
+			file: "path/to/Makefile",
+			want: RuleMap{"indent": {"style": "tab"}},
+		},
+		{
+			src: []Target{
+				{
+					Types: []string{"makefile", "shell"},
+					Rule:  RuleMap{"a": {"A": 1}},
+				},
+			},
+			file: "path/to/a.go",
+			want: RuleMap{},
+		},
+		{
+			src: []Target{
+				{
+					Patterns: []string{"scripts/**/*"},
+					Types:    []string{"shell"},
+					Rule:     RuleMap{"a": {"A": 1}},
+				},
+			},
+			file: "path/to/a.sh",
+			want: RuleMap{},
+		},
 	}
 
 	for _, tt := range tests {
 		c := &Config{Targets: tt.src}
-		got := c.MatchedRule(tt.file)
+		got, err := matchedRule(c, tt.file)
+		assert.NoError(t, err)
 		assert.Equal(t, tt.want, got)
 	}
 }
 
+func TestConfig_MatchedRule_Type(t *testing.T) {
+	c := &Config{
+		Targets: []Target{
+			{Types: []string{"makefile"}, Rule: RuleMap{"a": {"A": 1}}},
+			{Patterns: []string{"**/*.go"}, Types: []string{"go"}, Rule: RuleMap{"b": {"B": 1}}},
+			{Types: []string{"makefile", "shell"}, Rule: RuleMap{"c": {"C": 1}}},
+		},
+	}
+
+	// the type is given by the caller, which detects it once for the file
+	got, err := c.MatchedRule("path/to/build", "makefile")
+	assert.NoError(t, err)
+	assert.Equal(t, RuleMap{"a": {"A": 1}, "c": {"C": 1}}, got)
+
+	got, err = c.MatchedRule("path/to/build", "")
+	assert.NoError(t, err)
+	assert.Equal(t, RuleMap{}, got)
+}
+
+// matchedRule returns the rules applied to the file of the detected type.
+func matchedRule(cfg *Config, file string) (RuleMap, error) {
+	typ, _, err := DetectType(file)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.MatchedRule(file, typ)
+}
+
+func TestConfig_MatchedRule_InvalidPattern(t *testing.T) {
+	c := &Config{Targets: []Target{{Patterns: []string{"**/*.[ch"}}}}
+	_, err := matchedRule(c, "a.c")
+	assert.EqualError(t, err, `invalid glob pattern "**/*.[ch": unclosed '['`)
+}
+
 func TestRuleMap_Merge(t *testing.T) {
 	tests := []struct {
 		src  RuleMap
@@ -208,3 +330,39 @@ func TestRuleMap_Merge(t *testing.T) {
 		assert.Equal(t, tt.want, got)
 	}
 }
+
+func TestFile_Unmarshal(t *testing.T) {
+	tests := []struct {
+		src     string
+		want    File
+		wanterr bool
+	}{
+		{
+			src:  "max-file-size: 1024",
+			want: File{MaxFileSize: 1024},
+		},
+		{
+			src:  "max-file-size: 10MB\nlarge-file: stream",
+			want: File{MaxFileSize: 10 << 20, LargeFile: LargeFileStream},
+		},
+		{
+			src:     "max-file-size: 10XB",
+			wanterr: true,
+		},
+		{
+			src:     "large-file: ignore",
+			wanterr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		var got File
+		err := yaml.Unmarshal([]byte(tt.src), &got)
+		if tt.wanterr {
+			assert.Error(t, err, tt.src)
+			continue
+		}
+		assert.NoError(t, err, tt.src)
+		assert.Equal(t, tt.want, got, tt.src)
+	}
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/synchro-food/filelint/lint"
+)
+
+// The command line flags which change the rules. Their values can end with a
+// glob qualifier like "@**/*.md" to apply them only to the matching files.
+const (
+	// EnableFlag enforces the rule like "--enable indent"
+	EnableFlag = "--enable"
+
+	// DisableFlag disables the rule like "--disable no-eol-space"
+	DisableFlag = "--disable"
+
+	// SetFlag sets the option of the rule like "--set indent.size=2", and the
+	// value is read as YAML
+	SetFlag = "--set"
+
+	// SeverityFlag sets the severity of the rule like "--severity indent=warning"
+	SeverityFlag = "--severity"
+
+	// OnlyFlag runs only the rule like "--only linebreak", and the other rules
+	// are disabled by OnlyTarget
+	OnlyFlag = "--only"
+)
+
+// NewFlagTarget returns the target which applies the value of the flag such
+// as "indent.size=2@**/*.yml". The last "@" separates the glob qualifier, so
+// a value containing "@" must be followed by a qualifier like "@**/*".
+func NewFlagTarget(flag, value string) (Target, error) {
+	name := flag + " " + value
+
+	spec, pattern := value, "**/*"
+	if i := strings.LastIndex(value, "@"); i >= 0 {
+		spec, pattern = value[:i], value[i+1:]
+		if _, err := CompileGlob(pattern); err != nil {
+			return Target{}, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	rule, options, err := parseFlag(flag, spec)
+	if err != nil {
+		return Target{}, fmt.Errorf("%s: %v", name, err)
+	}
+	if err := validateFlag(name, rule, options); err != nil {
+		return Target{}, err
+	}
+
+	return Target{
+		Patterns: []string{pattern},
+		Rule:     RuleMap{rule: options},
+		origin:   &origin{file: name},
+	}, nil
+}
+
+// parseFlag returns the rule and its options set by the flag.
+func parseFlag(flag, spec string) (string, map[string]interface{}, error) {
+	switch flag {
+	case EnableFlag, OnlyFlag:
+		return spec, map[string]interface{}{lint.EnforceOption.Name: true}, nil
+
+	case DisableFlag:
+		return spec, map[string]interface{}{lint.EnforceOption.Name: false}, nil
+
+	case SeverityFlag:
+		i := strings.Index(spec, "=")
+		if i < 0 {
+			return "", nil, fmt.Errorf("must be RULE=SEVERITY")
+		}
+		return spec[:i], map[string]interface{}{lint.SeverityOption.Name: spec[i+1:]}, nil
+
+	case SetFlag:
+		i := strings.Index(spec, "=")
+		j := strings.Index(spec, ".")
+		if i < 0 || j < 0 || j > i {
+			return "", nil, fmt.Errorf("must be RULE.OPTION=VALUE")
+		}
+		var v interface{}
+		if err := yaml.Unmarshal([]byte(spec[i+1:]), &v); err != nil {
+			return "", nil, err
+		}
+		if v == nil {
+			v = ""
+		}
+		return spec[:j], map[string]interface{}{spec[j+1 : i]: v}, nil
+	}
+
+	return "", nil, fmt.Errorf("unknown flag")
+}
+
+// validateFlag validates the options set by the flag with the schema.
+func validateFlag(name, rule string, options map[string]interface{}) error {
+	m := make(map[interface{}]interface{}, len(options))
+	for k, v := range options {
+		m[k] = v
+	}
+
+	vd := &validator{file: name, positions: positions{}}
+	vd.validate(newRulesSchema(), map[interface{}]interface{}{rule: m}, "")
+	if len(vd.errs) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(vd.errs))
+	for _, e := range vd.errs {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", name, e.Message))
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "\n"))
+}
+
+// OnlyTarget returns the target which disables all rules except the rules of
+// --only flags, which are enabled by the following targets.
+func OnlyTarget() Target {
+	rules := make(RuleMap)
+	for _, name := range lint.GetDefinedRules().GetAllRuleNames() {
+		rules[name] = map[string]interface{}{lint.EnforceOption.Name: false}
+	}
+
+	return Target{
+		Patterns: []string{"**/*"},
+		Rule:     rules,
+		origin:   &origin{file: OnlyFlag},
+	}
+}
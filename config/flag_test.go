@@ -0,0 +1,139 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/synchro-food/filelint/lint"
+)
+
+func TestNewFlagTarget(t *testing.T) {
+	tests := []struct {
+		flag    string
+		value   string
+		pattern string
+		rules   RuleMap
+	}{
+		{
+			flag:    EnableFlag,
+			value:   "indent",
+			pattern: "**/*",
+			rules:   RuleMap{"indent": {"enforce": true}},
+		},
+		{
+			flag:    DisableFlag,
+			value:   "no-eol-space@**/*.md",
+			pattern: "**/*.md",
+			rules:   RuleMap{"no-eol-space": {"enforce": false}},
+		},
+		{
+			flag:    SetFlag,
+			value:   "indent.size=2@**/*.yml",
+			pattern: "**/*.yml",
+			rules:   RuleMap{"indent": {"size": 2}},
+		},
+		{
+			flag:    SetFlag,
+			value:   "unicode-nbsp.allow=[U+00A0]",
+			pattern: "**/*",
+			rules:   RuleMap{"unicode-nbsp": {"allow": []interface{}{"U+00A0"}}},
+		},
+		{
+			// the last "@" is the qualifier
+			flag:    SetFlag,
+			value:   "header.template=Copyright a@b.com@**/*",
+			pattern: "**/*",
+			rules:   RuleMap{"header": {"template": "Copyright a@b.com"}},
+		},
+		{
+			flag:    SetFlag,
+			value:   "header.template=",
+			pattern: "**/*",
+			rules:   RuleMap{"header": {"template": ""}},
+		},
+		{
+			flag:    SeverityFlag,
+			value:   "indent=warning",
+			pattern: "**/*",
+			rules:   RuleMap{"indent": {"severity": "warning"}},
+		},
+		{
+			flag:    OnlyFlag,
+			value:   "linebreak@*.bat",
+			pattern: "*.bat",
+			rules:   RuleMap{"linebreak": {"enforce": true}},
+		},
+	}
+
+	for _, tt := range tests {
+		target, err := NewFlagTarget(tt.flag, tt.value)
+		if !assert.NoError(t, err, tt.value) {
+			continue
+		}
+		assert.Equal(t, []string{tt.pattern}, target.Patterns, tt.value)
+		assert.Equal(t, tt.rules, target.Rule, tt.value)
+		assert.Equal(t, tt.flag+" "+tt.value, target.Origin())
+	}
+}
+
+func TestNewFlagTarget_Error(t *testing.T) {
+	tests := []struct {
+		flag  string
+		value string
+		want  string
+	}{
+		{EnableFlag, "indnt", `--enable indnt: unknown rule "indnt" (did you mean "indent"?)`},
+		{SetFlag, "indent", "--set indent: must be RULE.OPTION=VALUE"},
+		{SetFlag, "indent=2", "--set indent=2: must be RULE.OPTION=VALUE"},
+		{SetFlag, "indent.size=x", `--set indent.size=x: indent.size: must be an integer but string "x"`},
+		{SetFlag, "indent.sise=2", `--set indent.sise=2: indent: unknown option "sise" (did you mean "size"?)`},
+		{SeverityFlag, "indent", "--severity indent: must be RULE=SEVERITY"},
+		{SetFlag, "indent.severity=Error", `--set indent.severity=Error: indent.severity: must be one of error, warning but "Error"`},
+		{SeverityFlag, "indent=Warning", `--severity indent=Warning: indent.severity: must be one of error, warning but "Warning"`},
+		{SeverityFlag, "indent=info", `--severity indent=info: indent.severity: must be one of error, warning but "info"`},
+		{DisableFlag, "indent@**/*.[ch", `--disable indent@**/*.[ch: invalid glob pattern "**/*.[ch": unclosed '['`},
+	}
+
+	for _, tt := range tests {
+		_, err := NewFlagTarget(tt.flag, tt.value)
+		assert.EqualError(t, err, tt.want)
+	}
+}
+
+func TestOnlyTarget(t *testing.T) {
+	only, err := NewFlagTarget(OnlyFlag, "linebreak")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{Targets: []Target{
+		{Patterns: []string{"**/*"}, Rule: RuleMap{"linebreak": {"enforce": true, "style": "crlf"}, "no-bom": {"enforce": true}}},
+		OnlyTarget(),
+		only,
+	}}
+
+	rules, err := matchedRule(cfg, "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, options := range rules {
+		assert.Equal(t, name == "linebreak", options["enforce"], name)
+	}
+	assert.Equal(t, "crlf", rules["linebreak"]["style"])
+	assert.Equal(t, OnlyFlag, cfg.Targets[1].Origin())
+}
+
+func TestRuleMap_Severities(t *testing.T) {
+	rm := RuleMap{
+		"indent":    {"enforce": true, "severity": "warning"},
+		"linebreak": {"enforce": true, "severity": "error"},
+		"no-bom":    {"enforce": true},
+	}
+	severities, err := rm.Severities()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]lint.Severity{"indent": lint.SeverityWarning, "linebreak": lint.SeverityError}, severities)
+
+	// the severities are case-sensitive
+	_, err = RuleMap{"indent": {"severity": "Warning"}}.Severities()
+	assert.EqualError(t, err, `indent.severity: must be one of error, warning but "Warning"`)
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Glob is a compiled glob pattern.
+//
+// `*` matches any characters except `/`, `**/` matches zero or more
+// directories, `?` matches a character except `/`, `[...]` matches a character
+// in the class (`[!...]` or `[^...]` negates it), `{a,b}` matches either of the
+// alternatives and `\` escapes the next character.
+type Glob struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// GlobError is the error of an invalid glob pattern.
+type GlobError struct {
+	Pattern string
+	Reason  string
+}
+
+func (e *GlobError) Error() string {
+	return fmt.Sprintf("invalid glob pattern %q: %s", e.Pattern, e.Reason)
+}
+
+var globs sync.Map
+
+// CompileGlob parses the glob pattern.
+func CompileGlob(pattern string) (*Glob, error) {
+	if g, ok := globs.Load(pattern); ok {
+		return g.(*Glob), nil
+	}
+
+	p := pattern
+	if filepath.Separator == '\\' {
+		p = filepath.ToSlash(p)
+	}
+	p = path.Clean(p)
+
+	expr, err := translateGlob(p)
+	if err != nil {
+		return nil, &GlobError{Pattern: pattern, Reason: err.Error()}
+	}
+
+	// file names are case insensitive on these platforms
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		expr = "(?i)" + expr
+	}
+
+	g := &Glob{pattern: pattern, re: regexp.MustCompile("^" + expr + "$")}
+	globs.Store(pattern, g)
+
+	return g, nil
+}
+
+func (g *Glob) String() string {
+	return g.pattern
+}
+
+// Match reports whether the file path matches the pattern.
+func (g *Glob) Match(name string) bool {
+	name = filepath.ToSlash(name)
+	if name != "." {
+		name = strings.TrimPrefix(name, "./")
+	}
+	return g.re.MatchString(name)
+}
+
+// translateGlob returns the regular expression of the glob pattern.
+func translateGlob(p string) (string, error) {
+	var (
+		buf   strings.Builder
+		depth int
+	)
+
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+
+		switch c {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' && (i == 0 || p[i-1] == '/') {
+				switch {
+				case i+2 == len(p):
+					buf.WriteString(".*")
+					i++
+					continue
+				case p[i+2] == '/':
+					buf.WriteString("(?:.*/)?")
+					i += 2
+					continue
+				}
+			}
+			buf.WriteString("[^/]*")
+		case '?':
+			buf.WriteString("[^/]")
+		case '[':
+			class, n, err := translateClass(p[i:])
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString(class)
+			i += n - 1
+		case '{':
+			depth++
+			buf.WriteString("(?:")
+		case ',':
+			if depth > 0 {
+				buf.WriteByte('|')
+			} else {
+				buf.WriteByte(',')
+			}
+		case '}':
+			if depth == 0 {
+				return "", fmt.Errorf("unmatched '}' at %d", i)
+			}
+			depth--
+			buf.WriteByte(')')
+		case '\\':
+			if i+1 == len(p) {
+				return "", fmt.Errorf("trailing '\\'")
+			}
+			i++
+			buf.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		default:
+			buf.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+
+	if depth > 0 {
+		return "", fmt.Errorf("unclosed '{'")
+	}
+
+	return buf.String(), nil
+}
+
+// translateClass returns the regular expression of the character class at the
+// beginning of p and the length of the class in p.
+func translateClass(p string) (string, int, error) {
+	var buf strings.Builder
+	buf.WriteByte('[')
+
+	i := 1
+	negate := i < len(p) && (p[i] == '!' || p[i] == '^')
+	if negate {
+		buf.WriteByte('^')
+		i++
+	}
+
+	// `]` right after `[` or `[!` is a literal
+	start := i
+	for ; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case c == ']' && i > start:
+			if negate {
+				// a negated class never matches the separator
+				buf.WriteByte('/')
+			}
+			buf.WriteByte(']')
+			return buf.String(), i + 1, nil
+		case c == '\\':
+			if i+1 == len(p) {
+				return "", 0, fmt.Errorf("trailing '\\'")
+			}
+			i++
+			buf.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		case c == '-' && i > start && i+1 < len(p) && p[i+1] != ']':
+			if p[i+1] < p[i-1] {
+				return "", 0, fmt.Errorf("invalid range %q", p[i-1:i+2])
+			}
+			buf.WriteByte('-')
+		case c == '[' || c == ']' || c == '^':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("unclosed '['")
+}
+
+// EscapeGlob returns the pattern which matches only the path itself.
+func EscapeGlob(path string) string {
+	// `\` is the path separator on Windows
+	if filepath.Separator == '\\' {
+		return path
+	}
+
+	var buf strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`*?[]{}\!`, r) {
+			buf.WriteByte('\\')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
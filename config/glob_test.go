@@ -0,0 +1,74 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlob_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"**/*", "a", true},
+		{"**/*", "a/b/c", true},
+		{"./**/*", "a/b", true},
+		{"**/*.go", "a.go", true},
+		{"**/*.go", "a/b.go", true},
+		{"**/*.go", "a/b.go/c", false},
+		{"*.go", "a/b.go", false},
+		{"a/**/*", "a/b", true},
+		{"a/**/*", "b/a/c", false},
+		{"a/**", "a/b/c", true},
+		{".git/**/*", ".git/HEAD", true},
+		{"a?.txt", "ab.txt", true},
+		{"a?.txt", "a/.txt", false},
+		{"*.[ch]", "a.c", true},
+		{"*.[ch]", "a.o", false},
+		{"*.[!ch]", "a.o", true},
+		{"*.[a-c]", "a.b", true},
+		{"**/*.{md,mkd}", "a/b.mkd", true},
+		{"**/*.{md,mkd}", "a/b.txt", false},
+		{"{a,b/**}/*.txt", "b/c/d.txt", true},
+		{`\*.txt`, "*.txt", true},
+		{`\*.txt`, "a.txt", false},
+		{"a.txt", "./a.txt", true},
+		{"a+b(c).txt", "a+b(c).txt", true},
+	}
+
+	for _, tt := range tests {
+		g, err := CompileGlob(tt.pattern)
+		if assert.NoError(t, err, tt.pattern) {
+			assert.Equal(t, tt.want, g.Match(tt.name), tt.pattern+" "+tt.name)
+		}
+	}
+}
+
+func TestCompileGlob_Error(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"*.[ch", `invalid glob pattern "*.[ch": unclosed '['`},
+		{"*.{md,mkd", `invalid glob pattern "*.{md,mkd": unclosed '{'`},
+		{"*.md}", `invalid glob pattern "*.md}": unmatched '}' at 4`},
+		{"[z-a]", `invalid glob pattern "[z-a]": invalid range "z-a"`},
+		{`a\`, `invalid glob pattern "a\\": trailing '\'`},
+	}
+
+	for _, tt := range tests {
+		_, err := CompileGlob(tt.pattern)
+		assert.EqualError(t, err, tt.want)
+	}
+}
+
+func TestEscapeGlob(t *testing.T) {
+	for _, name := range []string{"a.txt", "a[1].txt", "{a}*?.txt", `a\b`, "!a"} {
+		g, err := CompileGlob(EscapeGlob(name))
+		if assert.NoError(t, err, name) {
+			assert.True(t, g.Match(name), name)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/synchro-food/filelint/lint"
+)
+
+// Inference is the settings of the rules inferred from the majority of files.
+// The settings may be changed before Generate.
+type Inference struct {
+	NumFiles int
+
+	Linebreak    string
+	FinalNewline int
+
+	// NoBOM is true unless the majority of files have a BOM
+	NoBOM bool
+
+	Indents []*IndentInference
+
+	linebreaks    votes
+	finalNewlines votes
+	boms          votes
+}
+
+// IndentInference is the indent style inferred from the files of an extension.
+type IndentInference struct {
+	// Name is the extension like ".go", or the file name if the files have no extension
+	Name  string
+	Style string
+
+	// NumFiles is the number of the indented files
+	NumFiles int
+
+	styles votes
+}
+
+// Pattern returns the target pattern of the files.
+func (ii *IndentInference) Pattern() string {
+	if strings.HasPrefix(ii.Name, ".") {
+		return "**/*" + EscapeGlob(ii.Name)
+	}
+	return "**/" + EscapeGlob(ii.Name)
+}
+
+// votes counts the files for each value, and elects the value of the majority.
+type votes map[string]int
+
+func (v votes) elect(fallback string) string {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	winner, max := fallback, 0
+	for _, k := range keys {
+		if v[k] > max {
+			winner, max = k, v[k]
+		}
+	}
+	return winner
+}
+
+// Infer infers the settings from the files.
+func Infer(files []string) (*Inference, error) {
+	inf := &Inference{
+		linebreaks:    make(votes),
+		finalNewlines: make(votes),
+		boms:          make(votes),
+	}
+
+	indents := make(map[string]votes)
+	for _, file := range files {
+		style, err := inspectFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if style == nil {
+			continue
+		}
+		inf.NumFiles++
+
+		inf.linebreaks[strings.ToLower(style.Linebreak.String())]++
+		inf.finalNewlines[fmt.Sprint(style.FinalNewlines)]++
+		inf.boms[fmt.Sprint(!style.BOM)]++
+
+		if style.Indent != "" {
+			name := filepath.Ext(file)
+			if name == "" {
+				name = filepath.Base(file)
+			}
+			if indents[name] == nil {
+				indents[name] = make(votes)
+			}
+			indents[name][string(style.Indent)]++
+		}
+	}
+
+	inf.Linebreak = inf.linebreaks.elect("lf")
+	fmt.Sscan(inf.finalNewlines.elect("1"), &inf.FinalNewline)
+	inf.NoBOM = inf.boms.elect("true") == "true"
+
+	for name, v := range indents {
+		ii := &IndentInference{
+			Name:   name,
+			Style:  v.elect(string(lint.SpaceIndent)),
+			styles: v,
+		}
+		for _, n := range v {
+			ii.NumFiles += n
+		}
+		inf.Indents = append(inf.Indents, ii)
+	}
+	sort.Slice(inf.Indents, func(i, j int) bool {
+		return inf.Indents[i].Name < inf.Indents[j].Name
+	})
+
+	return inf, nil
+}
+
+const (
+	// inferHeadSize is the size of the beginning of a file inspected for
+	// inference, which is enough to tell the style of the file without
+	// reading large files entirely
+	inferHeadSize = 64 * 1024
+
+	// inferTailSize is the size of the end of a file where the final
+	// newlines are counted if the file is larger than inferHeadSize
+	inferTailSize = 1024
+)
+
+// inspectFile returns the style of the file inspected from its bounded
+// beginning and end, or nil if the file is empty.
+func inspectFile(file string) (*lint.Style, error) {
+	fp, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	head := make([]byte, inferHeadSize)
+	n, err := io.ReadFull(fp, head)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// the whole file is read
+		if n == 0 {
+			return nil, nil
+		}
+		return lint.Inspect(head[:n]), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	style := lint.Inspect(head)
+
+	fi, err := fp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := fi.Size() - inferTailSize
+	if offset < inferHeadSize {
+		offset = inferHeadSize
+	}
+	tail := make([]byte, fi.Size()-offset)
+	if _, err := fp.ReadAt(tail, offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+	style.FinalNewlines = lint.Inspect(tail).FinalNewlines
+
+	return style, nil
+}
+
+// Generate returns the commented config file of the inferred settings.
+func (inf *Inference) Generate(f File) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# Filelint configuration generated by `filelint init`.\n")
+	fmt.Fprintf(&buf, "# The settings are inferred from %d file(s).\n", inf.NumFiles)
+	fmt.Fprintf(&buf, "# Run `filelint rules` to see all rules and their options.\n")
+	fmt.Fprintf(&buf, "\n")
+
+	fmt.Fprintf(&buf, "files:\n")
+	fmt.Fprintf(&buf, "  # the files to lint\n")
+	fmt.Fprintf(&buf, "  include:\n")
+	for _, p := range f.Include {
+		fmt.Fprintf(&buf, "    - %s\n", strconv.Quote(p))
+	}
+	fmt.Fprintf(&buf, "  # the files not to lint (.gitignore and .filelintignore files are also used)\n")
+	fmt.Fprintf(&buf, "  exclude:\n")
+	for _, p := range f.Exclude {
+		fmt.Fprintf(&buf, "    - %s\n", strconv.Quote(p))
+	}
+	fmt.Fprintf(&buf, "\n")
+
+	fmt.Fprintf(&buf, "targets:\n")
+	fmt.Fprintf(&buf, "  # the rules for all files\n")
+	fmt.Fprintf(&buf, "  - patterns: ['**/*']\n")
+	fmt.Fprintf(&buf, "    rules:\n")
+	fmt.Fprintf(&buf, "      # %d of %d file(s) use %s\n", inf.linebreaks[inf.Linebreak], inf.NumFiles, strings.ToUpper(inf.Linebreak))
+	fmt.Fprintf(&buf, "      linebreak:\n")
+	fmt.Fprintf(&buf, "        enforce: true\n")
+	fmt.Fprintf(&buf, "        style: %s\n", inf.Linebreak)
+	fmt.Fprintf(&buf, "      first-newline:\n")
+	fmt.Fprintf(&buf, "        enforce: true\n")
+	fmt.Fprintf(&buf, "        num: 0\n")
+	fmt.Fprintf(&buf, "      # %d of %d file(s) end with %d newline(s)\n", inf.finalNewlines[fmt.Sprint(inf.FinalNewline)], inf.NumFiles, inf.FinalNewline)
+	fmt.Fprintf(&buf, "      final-newline:\n")
+	fmt.Fprintf(&buf, "        enforce: true\n")
+	fmt.Fprintf(&buf, "        num: %d\n", inf.FinalNewline)
+	fmt.Fprintf(&buf, "      # %d of %d file(s) have a BOM\n", inf.boms["false"], inf.NumFiles)
+	fmt.Fprintf(&buf, "      no-bom:\n")
+	fmt.Fprintf(&buf, "        enforce: %t\n", inf.NoBOM)
+	fmt.Fprintf(&buf, "      no-eol-space:\n")
+	fmt.Fprintf(&buf, "        enforce: true\n")
+
+	for _, ii := range inf.Indents {
+		fmt.Fprintf(&buf, "\n")
+		fmt.Fprintf(&buf, "  # %d of %d indented %s file(s) use %s indentation\n", ii.styles[ii.Style], ii.NumFiles, ii.Name, ii.Style)
+		fmt.Fprintf(&buf, "  - patterns: [%s]\n", strconv.Quote(ii.Pattern()))
+		fmt.Fprintf(&buf, "    rules:\n")
+		fmt.Fprintf(&buf, "      indent:\n")
+		fmt.Fprintf(&buf, "        enforce: true\n")
+		fmt.Fprintf(&buf, "        style: %s\n", ii.Style)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestInfer(t *testing.T) {
+	_, cleanup := setupTree(t, map[string]string{
+		"a.go":     "package a\n\nfunc a() {\n\treturn\n}\n",
+		"b.go":     "package b\n\nfunc b() {\n    return\n}\n",
+		"c.go":     "package c\n\nfunc c() {\n\treturn\n}\n",
+		"a.py":     "def a():\r\n    pass\r\n\r\n",
+		"Makefile": "\xef\xbb\xbfall:\n\techo\n",
+		"empty":    "",
+	})
+	defer cleanup()
+
+	inf, err := Infer([]string{"a.go", "b.go", "c.go", "a.py", "Makefile", "empty"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 5, inf.NumFiles)
+	assert.Equal(t, "lf", inf.Linebreak)
+	assert.Equal(t, 1, inf.FinalNewline)
+	assert.True(t, inf.NoBOM)
+
+	if assert.Len(t, inf.Indents, 3) {
+		assert.Equal(t, ".go", inf.Indents[0].Name)
+		assert.Equal(t, "tab", inf.Indents[0].Style)
+		assert.Equal(t, 3, inf.Indents[0].NumFiles)
+		assert.Equal(t, "**/*.go", inf.Indents[0].Pattern())
+
+		assert.Equal(t, ".py", inf.Indents[1].Name)
+		assert.Equal(t, "space", inf.Indents[1].Style)
+
+		assert.Equal(t, "Makefile", inf.Indents[2].Name)
+		assert.Equal(t, "**/Makefile", inf.Indents[2].Pattern())
+	}
+}
+
+func TestInference_Generate(t *testing.T) {
+	_, cleanup := setupTree(t, map[string]string{
+		"a.go": "package a\n\nfunc a() {\n\treturn\n}\n",
+		"a.md": "a\r\n\r\n",
+	})
+	defer cleanup()
+
+	inf, err := Infer([]string{"a.go", "a.md"})
+	assert.NoError(t, err)
+	inf.Linebreak = "crlf"
+	inf.NoBOM = false
+
+	def, err := NewDefaultConfig()
+	assert.NoError(t, err)
+
+	src := inf.Generate(def.File)
+	assert.NoError(t, Validate(FileName, src))
+	assert.Contains(t, string(src), "# 1 of 2 file(s) use CRLF\n")
+
+	cfg := &Config{}
+	assert.NoError(t, yaml.Unmarshal(src, cfg))
+	assert.Equal(t, def.File.Include, cfg.File.Include)
+	assert.Equal(t, def.File.Exclude, cfg.File.Exclude)
+
+	rules, err := matchedRule(cfg, "a.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "crlf", rules["linebreak"]["style"])
+	assert.Equal(t, false, rules["no-bom"]["enforce"])
+	assert.Equal(t, "tab", rules["indent"]["style"])
+	rules, err = matchedRule(cfg, "a.md")
+	assert.NoError(t, err)
+	assert.Nil(t, rules["indent"])
+}
+
+func TestInference_Generate_Quote(t *testing.T) {
+	_, cleanup := setupTree(t, map[string]string{
+		"say\"hi\\": "a\n\tb\n",
+	})
+	defer cleanup()
+
+	inf, err := Infer([]string{"say\"hi\\"})
+	assert.NoError(t, err)
+
+	f := File{Include: []string{"src/\"a\"/**/*"}, Exclude: []string{"it's/**/*"}}
+	src := inf.Generate(f)
+	assert.NoError(t, Validate(FileName, src))
+
+	// the patterns are written as they are, and the file name is escaped
+	cfg := &Config{}
+	assert.NoError(t, yaml.Unmarshal(src, cfg))
+	assert.Equal(t, f.Include, cfg.File.Include)
+	assert.Equal(t, f.Exclude, cfg.File.Exclude)
+	if assert.Len(t, cfg.Targets, 2) {
+		assert.Equal(t, []string{"**/say\"hi\\\\"}, cfg.Targets[1].Patterns)
+		rules, err := matchedRule(cfg, "say\"hi\\")
+		assert.NoError(t, err)
+		assert.Equal(t, "tab", rules["indent"]["style"])
+	}
+}
+
+func TestInfer_LargeFile(t *testing.T) {
+	// the indent after the beginning of the file is not inspected, but the
+	// final newlines are counted at the end
+	src := strings.Repeat("a\r\n", inferHeadSize/3+1) + strings.Repeat("\tb\r\n", 100) + "\r\n"
+	_, cleanup := setupTree(t, map[string]string{
+		"large.txt": src,
+	})
+	defer cleanup()
+
+	inf, err := Infer([]string{"large.txt"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, inf.NumFiles)
+	assert.Equal(t, "crlf", inf.Linebreak)
+	assert.Equal(t, 2, inf.FinalNewline)
+	assert.Empty(t, inf.Indents)
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/synchro-food/filelint/lint"
+)
+
+// DefaultConfigName is the name of the application default config in messages.
+const DefaultConfigName = "(default)"
+
+// origin is where a target is defined.
+type origin struct {
+	// file is the config file, or the name of the flag
+	file string
+
+	// path is the path of the target in the file like "targets[1]",
+	// or empty if the source is the rule map itself
+	path string
+
+	// positions is nil if the target has no source such as --set flag
+	positions positions
+}
+
+func (o *origin) at(path string) string {
+	if o.positions == nil {
+		return o.file
+	}
+	return fmt.Sprintf("%s:%s", o.file, o.positions.lookup(path))
+}
+
+// rulePath returns the path of the option of the rule, or of the rule if
+// option is empty.
+func (o *origin) rulePath(rule, option string) string {
+	p := joinPath(joinPath(o.path, "rules"), rule)
+	if o.path == "" {
+		p = rule
+	}
+	if option == "" {
+		return p
+	}
+	return joinPath(p, option)
+}
+
+// setOrigins records the origins of the targets defined in src.
+func setOrigins(targets []Target, file string, src []byte) {
+	ps := locate(src)
+	for i := range targets {
+		targets[i].origin = &origin{
+			file:      file,
+			path:      fmt.Sprintf("targets[%d]", i),
+			positions: ps,
+		}
+	}
+}
+
+// NewRuleTarget returns the target which applies the rule map in src, such as
+// the value of --rule flag, to all files. name is used in the messages.
+func NewRuleTarget(name string, src []byte) (Target, error) {
+	if err := ValidateRules(name, src); err != nil {
+		return Target{}, err
+	}
+
+	rules := make(RuleMap)
+	if err := yaml.Unmarshal(src, &rules); err != nil {
+		return Target{}, err
+	}
+
+	return Target{
+		Patterns: []string{"**/*"},
+		Rule:     rules,
+		origin:   &origin{file: name, positions: locate(src)},
+	}, nil
+}
+
+// Origin returns where the target is defined like ".filelint.yml:7:5 targets[1]",
+// or empty if it is unknown.
+func (t Target) Origin() string {
+	if t.origin == nil {
+		return ""
+	}
+	if t.origin.path == "" {
+		return t.origin.file
+	}
+	return t.origin.at(t.origin.path) + " " + t.origin.path
+}
+
+// OptionOrigin returns where the option of the rule is set in the target, or
+// where the rule is set if option is empty.
+func (t Target) OptionOrigin(rule, option string) string {
+	if t.origin == nil {
+		return ""
+	}
+	p := t.origin.rulePath(rule, option)
+	return t.origin.at(p) + " " + p
+}
+
+// RuleOrigin returns where the rule applied to the file of the type is
+// configured, which is the last target matching the file and having the rule,
+// or empty if it is unknown.
+func (cfg *Config) RuleOrigin(file, typ, rule string) (string, error) {
+	indexes, err := cfg.MatchedTargets(file, typ)
+	if err != nil {
+		return "", err
+	}
+
+	for i := len(indexes) - 1; i >= 0; i-- {
+		t := cfg.Targets[indexes[i]]
+		if _, ok := t.Rule[rule]; ok {
+			return t.OptionOrigin(rule, ""), nil
+		}
+	}
+	return "", nil
+}
+
+// RuleOption is the value of a rule option applied to a file.
+type RuleOption struct {
+	Name  string
+	Value interface{}
+
+	// Origin is where the value is set, or empty if it is the default value of
+	// the rule
+	Origin string
+}
+
+// RuleOptions is the options of a rule applied to a file.
+type RuleOptions struct {
+	Rule    string
+	Options []*RuleOption
+}
+
+// Enforced reports whether the rule is applied.
+func (ro *RuleOptions) Enforced() bool {
+	for _, o := range ro.Options {
+		if o.Name == lint.EnforceOption.Name {
+			return o.Value == true
+		}
+	}
+	return false
+}
+
+// ExplainRules returns the options of the rules configured for the file of the
+// type with where each value is set. The options which are not set are filled
+// with the default values of the rules.
+func (cfg *Config) ExplainRules(file, typ string) ([]*RuleOptions, error) {
+	indexes, err := cfg.MatchedTargets(file, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]map[string]*RuleOption)
+	for _, i := range indexes {
+		t := cfg.Targets[i]
+		for rule, options := range t.Rule {
+			if set[rule] == nil {
+				set[rule] = make(map[string]*RuleOption)
+			}
+			for name, value := range options {
+				set[rule][name] = &RuleOption{
+					Name:   name,
+					Value:  value,
+					Origin: t.OptionOrigin(rule, name),
+				}
+			}
+		}
+	}
+
+	rules := make([]string, 0, len(set))
+	for rule := range set {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+
+	ret := make([]*RuleOptions, 0, len(rules))
+	for _, rule := range rules {
+		ro := &RuleOptions{Rule: rule}
+
+		var names []string
+		if r := lint.GetDefinedRules().Get(rule); r != nil {
+			for _, o := range r.MetaData().AllOptions() {
+				names = append(names, o.Name)
+				if _, ok := set[rule][o.Name]; !ok {
+					set[rule][o.Name] = &RuleOption{Name: o.Name, Value: o.Default}
+				}
+			}
+		}
+		// unknown options are also shown
+		var unknown []string
+		for name := range set[rule] {
+			if !contains(names, name) {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		names = append(names, unknown...)
+
+		for _, name := range names {
+			ro.Options = append(ro.Options, set[rule][name])
+		}
+		ret = append(ret, ro)
+	}
+
+	return ret, nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ExplainRules(t *testing.T) {
+	_, cleanup := setupTree(t, map[string]string{
+		"a.md": "a",
+		"b.go": "b",
+	})
+	defer cleanup()
+
+	src := `targets:
+  - patterns:
+      - '**/*'
+    rules:
+      linebreak:
+        style: crlf
+  - patterns:
+      - '**/*.md'
+    rules:
+      no-eol-space:
+        enforce: false
+`
+	if err := ioutil.WriteFile(FileName, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewConfig(FileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := len(cfg.Targets)
+	assert.Equal(t, ".filelint.yml:2:3 targets[0]", cfg.Targets[n-2].Origin())
+	assert.Equal(t, ".filelint.yml:7:3 targets[1]", cfg.Targets[n-1].Origin())
+	assert.Contains(t, cfg.Targets[0].Origin(), DefaultConfigName+":")
+
+	rule, err := NewRuleTarget("--rule", []byte("indent: {style: tab}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "--rule", rule.Origin())
+	cfg.Targets = append(cfg.Targets, rule)
+
+	rules, err := cfg.ExplainRules("a.md", "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := make(map[string]*RuleOption)
+	enforced := make(map[string]bool)
+	for _, ro := range rules {
+		enforced[ro.Rule] = ro.Enforced()
+		for _, o := range ro.Options {
+			options[ro.Rule+"."+o.Name] = o
+		}
+	}
+
+	assert.Equal(t, "crlf", options["linebreak.style"].Value)
+	assert.Equal(t, ".filelint.yml:6:9 targets[0].rules.linebreak.style", options["linebreak.style"].Origin)
+	assert.False(t, enforced["no-eol-space"])
+	assert.Equal(t, ".filelint.yml:11:9 targets[1].rules.no-eol-space.enforce", options["no-eol-space.enforce"].Origin)
+
+	// the options not set are the defaults of the rule
+	assert.Equal(t, "tab", options["indent.style"].Value)
+	assert.Equal(t, "--rule:1:10 indent.style", options["indent.style"].Origin)
+	assert.Equal(t, 4, options["indent.size"].Value)
+	assert.Equal(t, "", options["indent.size"].Origin)
+
+	// the rule is configured by the last target having it
+	origin, err := cfg.RuleOrigin("a.md", "markdown", "no-eol-space")
+	assert.NoError(t, err)
+	assert.Equal(t, ".filelint.yml:10:7 targets[1].rules.no-eol-space", origin)
+	origin, err = cfg.RuleOrigin("b.go", "go", "no-eol-space")
+	assert.NoError(t, err)
+	assert.Contains(t, origin, DefaultConfigName+":")
+	origin, err = cfg.RuleOrigin("a.md", "markdown", "indent")
+	assert.NoError(t, err)
+	assert.Equal(t, "--rule:1:1 indent", origin)
+	origin, err = cfg.RuleOrigin("a.md", "markdown", "header")
+	assert.NoError(t, err)
+	assert.Equal(t, "", origin)
+
+	rules, err = cfg.ExplainRules("b.go", "go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ro := range rules {
+		if ro.Rule == "no-eol-space" {
+			assert.True(t, ro.Enforced())
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Position is a position in the config file.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (pos Position) String() string {
+	return fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+}
+
+// positions maps the paths of the nodes such as "targets[0].rules.linebreak"
+// to their positions in the YAML source.
+type positions map[string]Position
+
+// lookup returns the position of the path, or of the nearest ancestor if the
+// path is not found.
+func (ps positions) lookup(path string) Position {
+	for path != "" {
+		if pos, ok := ps[path]; ok {
+			return pos
+		}
+
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return Position{Line: 1, Column: 1}
+}
+
+type positionFrame struct {
+	indent int
+	path   string
+	seq    int
+
+	// isKey is true if the frame is a mapping key whose value follows
+	isKey bool
+
+	// isBlock is true if the frame is a block scalar like `|` or `>`
+	isBlock bool
+}
+
+// locate indexes the positions of the nodes in the YAML source.
+// It supports block mappings and sequences, and flow collections written in
+// a line, which are enough for config files.
+func locate(src []byte) positions {
+	ps := make(positions)
+	stack := []*positionFrame{{indent: -1}}
+
+	for n, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+
+		if top := stack[len(stack)-1]; top.isBlock {
+			if indent > top.indent || strings.TrimSpace(line) == "" {
+				continue
+			}
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" || trimmed == "..." {
+			continue
+		}
+
+		isItem := trimmed == "-" || strings.HasPrefix(trimmed, "- ")
+
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+			if top.indent < indent {
+				break
+			}
+			// a sequence can be written at the same indent as the key
+			if top.indent == indent && top.isKey && isItem {
+				break
+			}
+			stack = stack[:len(stack)-1]
+		}
+
+		parent := stack[len(stack)-1]
+		col := indent
+
+		for isItem {
+			path := fmt.Sprintf("%s[%d]", parent.path, parent.seq)
+			parent.seq++
+			ps[path] = Position{Line: n + 1, Column: col + 1}
+
+			item := &positionFrame{indent: col, path: path}
+			stack = append(stack, item)
+			parent = item
+
+			rest := strings.TrimLeft(trimmed[1:], " ")
+			col += len(trimmed) - len(rest)
+			trimmed = rest
+			isItem = trimmed == "-" || strings.HasPrefix(trimmed, "- ")
+		}
+
+		if trimmed == "" {
+			continue
+		}
+
+		key, value, ok := splitKeyValue(trimmed)
+		if !ok {
+			if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+				locateFlow(ps, parent.path, trimmed, n+1, col+1)
+			}
+			continue
+		}
+
+		path := joinPath(parent.path, key)
+		ps[path] = Position{Line: n + 1, Column: col + 1}
+
+		valueCol := col + len(trimmed) - len(value)
+		switch {
+		case value == "" || strings.HasPrefix(value, "#"):
+			stack = append(stack, &positionFrame{indent: col, path: path, isKey: true})
+		case strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">"):
+			stack = append(stack, &positionFrame{indent: col, path: path, isBlock: true})
+		case strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{"):
+			locateFlow(ps, path, value, n+1, valueCol+1)
+		}
+	}
+
+	return ps
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// splitKeyValue splits `key: value` into the key and the value.
+func splitKeyValue(s string) (key, value string, ok bool) {
+	if s[0] == '"' || s[0] == '\'' {
+		end := strings.IndexByte(s[1:], s[0])
+		if end < 0 {
+			return "", "", false
+		}
+		key = s[1 : end+1]
+		s = s[end+2:]
+		if !strings.HasPrefix(s, ":") {
+			return "", "", false
+		}
+		return key, strings.TrimLeft(s[1:], " "), true
+	}
+
+	if s[0] == '[' || s[0] == '{' {
+		return "", "", false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ') {
+			return strings.TrimSpace(s[:i]), strings.TrimLeft(s[i+1:], " "), true
+		}
+		if s[i] == ' ' && i+1 < len(s) && s[i+1] == '#' {
+			break
+		}
+	}
+
+	return "", "", false
+}
+
+// locateFlow indexes the positions in the flow collection like `[a, b]` or
+// `{a: 1, b: 2}` beginning at line:col.
+func locateFlow(ps positions, path, s string, line, col int) {
+	if s == "" {
+		return
+	}
+
+	open := s[0]
+	var close byte
+	switch open {
+	case '[':
+		close = ']'
+	case '{':
+		close = '}'
+	default:
+		return
+	}
+
+	depth := 0
+	var quote byte
+	start := 1
+	index := 0
+
+	item := func(end int) {
+		raw := s[start:end]
+		text := strings.TrimLeft(raw, " ")
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		itemCol := col + start + len(raw) - len(text)
+
+		if open == '[' {
+			p := fmt.Sprintf("%s[%d]", path, index)
+			index++
+			ps[p] = Position{Line: line, Column: itemCol}
+			locateFlow(ps, p, text, line, itemCol)
+			return
+		}
+
+		key, value, ok := splitKeyValue(text)
+		if !ok {
+			return
+		}
+		p := joinPath(path, key)
+		ps[p] = Position{Line: line, Column: itemCol}
+		locateFlow(ps, p, value, line, itemCol+len(text)-len(value))
+	}
+
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case (c == ']' || c == '}') && depth > 0:
+			depth--
+		case c == ',' && depth == 0:
+			item(i)
+			start = i + 1
+		case c == close && depth == 0:
+			item(i)
+			return
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// presetDir is the directory of the built-in targets library in the assets.
+// Each preset is a config file which has only targets, and it is referenced
+// by the name without the extension like `use: [markdown]`.
+const presetDir = "config/presets"
+
+// PresetNames returns the sorted names of the built-in presets.
+func PresetNames() []string {
+	files, err := AssetDir(presetDir)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, strings.TrimSuffix(f, path.Ext(f)))
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// presetName is the name of the preset in messages like "(preset markdown)".
+func presetName(name string) string {
+	return fmt.Sprintf("(preset %s)", name)
+}
+
+// LoadPreset returns the targets of the built-in preset.
+func LoadPreset(name string) ([]Target, error) {
+	src, err := Asset(path.Join(presetDir, name+".yml"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown preset: %q (available: %s)", name, strings.Join(PresetNames(), ", "))
+	}
+
+	conf := &Config{}
+	if err := yaml.Unmarshal(src, conf); err != nil {
+		return nil, err
+	}
+	setOrigins(conf.Targets, presetName(name), src)
+
+	return conf.Targets, nil
+}
+
+// expandPresets inserts the targets of the presets in Use before the targets
+// of the config, so that the targets written in the config take precedence.
+func (cfg *Config) expandPresets() error {
+	var targets []Target
+	for _, name := range cfg.Use {
+		ts, err := LoadPreset(name)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, ts...)
+	}
+
+	cfg.Targets = append(targets, cfg.Targets...)
+	cfg.Use = nil
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresetNames(t *testing.T) {
+	assert.Equal(t, []string{"batch", "go", "json", "makefile", "markdown", "python", "toml", "yaml"}, PresetNames())
+}
+
+func TestLoadPreset(t *testing.T) {
+	_, cleanup := setupTree(t, map[string]string{
+		"a.bat":     "",
+		"a.go":      "",
+		"a.json":    "",
+		"Makefile":  "",
+		"a.md":      "",
+		"a.py":      "",
+		"a.toml":    "",
+		"a.yml":     "",
+		"a.txt":     "",
+		"script.sh": "",
+	})
+	defer cleanup()
+
+	// the presets are pinned so that changing them is a conscious decision
+	tests := []struct {
+		name string
+		file string
+		want RuleMap
+	}{
+		{
+			name: "batch",
+			file: "a.bat",
+			want: RuleMap{"linebreak": {"enforce": true, "style": "crlf"}},
+		},
+		{
+			name: "go",
+			file: "a.go",
+			want: RuleMap{"indent": {"enforce": true, "style": "tab", "size": 8}},
+		},
+		{
+			name: "json",
+			file: "a.json",
+			want: RuleMap{"valid-json": {"enforce": true}},
+		},
+		{
+			name: "makefile",
+			file: "Makefile",
+			want: RuleMap{"indent": {"enforce": true, "style": "tab", "size": 8}},
+		},
+		{
+			name: "markdown",
+			file: "a.md",
+			want: RuleMap{
+				"no-eol-space":                     {"allow-markdown-hard-break": true},
+				"markdown-heading-spacing":         {"enforce": true},
+				"markdown-list-marker":             {"enforce": true, "style": "consistent"},
+				"markdown-no-multiple-blank-lines": {"enforce": true},
+			},
+		},
+		{
+			name: "python",
+			file: "a.py",
+			want: RuleMap{"indent": {"enforce": true, "style": "space", "size": 4}},
+		},
+		{
+			name: "toml",
+			file: "a.toml",
+			want: RuleMap{"valid-toml": {"enforce": true}},
+		},
+		{
+			name: "yaml",
+			file: "a.yml",
+			want: RuleMap{
+				"indent":     {"enforce": true, "style": "space", "size": 2},
+				"valid-yaml": {"enforce": true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		src, err := Asset(presetDir + "/" + tt.name + ".yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NoError(t, Validate(tt.name+".yml", src))
+
+		targets, err := LoadPreset(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg := &Config{Targets: targets}
+		assert.Contains(t, targets[0].Origin(), "(preset "+tt.name+"):")
+
+		got, err := matchedRule(cfg, tt.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.want, got, tt.name)
+
+		// the presets don't touch the other files
+		for _, file := range []string{"a.txt", "script.sh"} {
+			got, err := matchedRule(cfg, file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Empty(t, got, "%s: %s", tt.name, file)
+		}
+	}
+
+	_, err := LoadPreset("cobol")
+	assert.EqualError(t, err, `unknown preset: "cobol" (available: batch, go, json, makefile, markdown, python, toml, yaml)`)
+}
+
+func TestNewDefaultConfig_Targets(t *testing.T) {
+	_, cleanup := setupTree(t, map[string]string{
+		"Makefile": "",
+		"a.md":     "",
+	})
+	defer cleanup()
+
+	// the default config keeps the targets which the presets repeat
+	cfg, err := NewDefaultConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := matchedRule(cfg, "Makefile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]interface{}{"enforce": true, "style": "tab"}, rules["indent"])
+
+	rules, err = matchedRule(cfg, "a.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]interface{}{"enforce": true, "allow-markdown-hard-break": true}, rules["no-eol-space"])
+}
+
+func TestNewConfig_Use(t *testing.T) {
+	_, cleanup := setupTree(t, map[string]string{
+		"a.md":  "",
+		"a.bat": "",
+	})
+	defer cleanup()
+
+	src := `use: [markdown, batch]
+targets:
+  - types: [markdown]
+    rules:
+      markdown-list-marker:
+        style: dash
+`
+	if err := ioutil.WriteFile(FileName, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewConfig(FileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, cfg.Use)
+
+	// the presets are inserted between the default config and the targets
+	n := len(cfg.Targets)
+	assert.Contains(t, cfg.Targets[n-3].Origin(), "(preset markdown):")
+	assert.Contains(t, cfg.Targets[n-2].Origin(), "(preset batch):")
+	assert.Equal(t, ".filelint.yml:3:3 targets[0]", cfg.Targets[n-1].Origin())
+
+	rules, err := matchedRule(cfg, "a.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]interface{}{"enforce": true, "style": "dash"}, rules["markdown-list-marker"])
+
+	rules, err = matchedRule(cfg, "a.bat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]interface{}{"enforce": true, "style": "crlf"}, rules["linebreak"])
+
+	if err := ioutil.WriteFile(FileName, []byte("use: [cobol]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewConfig(FileName)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), ".filelint.yml:1:7: use[0]:")
+	}
+}
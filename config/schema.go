@@ -0,0 +1,165 @@
+package config
+
+import (
+	"github.com/synchro-food/filelint/filetype"
+	"github.com/synchro-food/filelint/lint"
+)
+
+// Schema is the JSON Schema of the config file.
+// It is also used for validating config files.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+
+	// keyName is the name of the property keys used in error messages
+	keyName string
+
+	// patternName describes Pattern in error messages
+	patternName string
+
+	// glob is true if the string is a glob pattern
+	glob bool
+}
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+var noAdditionalProperties = false
+
+// minFileSize is the minimum of max-file-size, where 0 means unlimited
+var minFileSize = 0
+
+// NewSchema returns the schema of the config file including all defined rules.
+func NewSchema() *Schema {
+	return &Schema{
+		Schema:               jsonSchemaDraft,
+		Title:                "filelint config",
+		Description:          "the configuration file of filelint (.filelint.yml)",
+		Type:                 "object",
+		AdditionalProperties: &noAdditionalProperties,
+		Properties: map[string]*Schema{
+			"files": {
+				Description:          "lint target files",
+				Type:                 "object",
+				AdditionalProperties: &noAdditionalProperties,
+				Properties: map[string]*Schema{
+					"include": {
+						Description: "glob patterns of the files to lint",
+						Type:        "array",
+						Items:       globSchema,
+					},
+					"exclude": {
+						Description: "glob patterns of the files not to lint",
+						Type:        "array",
+						Items:       globSchema,
+					},
+					"max-file-size": {
+						Description: `the size limit of files such as 1048576 or "10MB", 0 means unlimited`,
+						AnyOf: []*Schema{
+							{Type: "integer", Minimum: &minFileSize},
+							{Type: "string", Pattern: sizePattern, patternName: `a size such as "100KB" or "10MB"`},
+						},
+					},
+					"large-file": {
+						Description: "how to handle the files larger than max-file-size",
+						Type:        "string",
+						Enum:        []string{string(LargeFileSkip), string(LargeFileWarn), string(LargeFileStream)},
+					},
+				},
+			},
+			"use": {
+				Description: "names of the built-in presets, which are the targets for the file types inserted before targets",
+				Type:        "array",
+				Items:       &Schema{Type: "string", Enum: PresetNames()},
+			},
+			"targets": {
+				Description: "rules applied to the files matching the patterns, later targets take precedence",
+				Type:        "array",
+				Items: &Schema{
+					Type:                 "object",
+					AdditionalProperties: &noAdditionalProperties,
+					Properties: map[string]*Schema{
+						"patterns": {
+							Description: `glob patterns of the files which the rules are applied to, patterns starting with "!" exclude the files matched by the preceding patterns`,
+							Type:        "array",
+							Items:       globSchema,
+						},
+						"excludePatterns": {
+							Description: "glob patterns of the files which the rules are not applied to",
+							Type:        "array",
+							Items:       globSchema,
+						},
+						"types": {
+							Description: "file types which the rules are applied to, detected from the file names, extensions, shebangs and modelines",
+							Type:        "array",
+							Items:       &Schema{Type: "string", Enum: filetype.Names()},
+						},
+						"rules": newRulesSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+var globSchema = &Schema{Type: "string", glob: true}
+
+const sizePattern = "^ *[0-9]+ *([KkMmGg]?[Bb])? *$"
+
+// newRulesSchema returns the schema of the rule map.
+func newRulesSchema() *Schema {
+	s := &Schema{
+		Description:          "rule names and their options",
+		Type:                 "object",
+		AdditionalProperties: &noAdditionalProperties,
+		Properties:           make(map[string]*Schema),
+		keyName:              "rule",
+	}
+
+	rules := lint.GetDefinedRules()
+	for _, name := range rules.GetAllRuleNames() {
+		md := rules.Get(name).MetaData()
+
+		rs := &Schema{
+			Description:          md.Description,
+			Type:                 "object",
+			AdditionalProperties: &noAdditionalProperties,
+			Properties:           make(map[string]*Schema),
+			keyName:              "option",
+		}
+		for _, o := range md.AllOptions() {
+			rs.Properties[o.Name] = newOptionSchema(o)
+		}
+
+		s.Properties[name] = rs
+	}
+
+	return s
+}
+
+func newOptionSchema(o *lint.Option) *Schema {
+	s := &Schema{
+		Description: o.Description,
+		Type:        string(o.Type),
+		Default:     o.Default,
+		Enum:        o.Enum,
+		Minimum:     o.Min,
+		Maximum:     o.Max,
+	}
+	if o.Type == lint.StringsOption {
+		// Enum of array options is the available values of the items
+		s.Items = &Schema{Type: "string", Enum: o.Enum}
+		s.Enum = nil
+	}
+	return s
+}
@@ -0,0 +1,349 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ValidationError is an invalid setting in the config file.
+type ValidationError struct {
+	File     string
+	Position Position
+	Message  string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Position.Column == 0 {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Position.Line, e.Message)
+	}
+	return fmt.Sprintf("%s:%s: %s", e.File, e.Position, e.Message)
+}
+
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Validate validates the YAML source of the config file with the schema.
+func Validate(file string, src []byte) error {
+	return validate(file, src, NewSchema())
+}
+
+// ValidateRules validates the YAML source of the rule map such as the value
+// of --rule flag.
+func ValidateRules(name string, src []byte) error {
+	return validate(name, src, newRulesSchema())
+}
+
+var yamlErrorRegexp = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+func validate(file string, src []byte, s *Schema) error {
+	var v interface{}
+	if err := yaml.Unmarshal(src, &v); err != nil {
+		if m := yamlErrorRegexp.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			return ValidationErrors{{File: file, Position: Position{Line: line}, Message: m[2]}}
+		}
+		return ValidationErrors{{File: file, Position: Position{Line: 1}, Message: err.Error()}}
+	}
+
+	vd := &validator{file: file, positions: locate(src)}
+	vd.validate(s, v, "")
+
+	if len(vd.errs) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(vd.errs, func(i, j int) bool {
+		a, b := vd.errs[i].Position, vd.errs[j].Position
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return vd.errs
+}
+
+type validator struct {
+	file      string
+	positions positions
+	errs      ValidationErrors
+}
+
+func (vd *validator) errorf(path, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if path != "" {
+		msg = path + ": " + msg
+	}
+	vd.errs = append(vd.errs, &ValidationError{
+		File:     vd.file,
+		Position: vd.positions.lookup(path),
+		Message:  msg,
+	})
+}
+
+func (vd *validator) validate(s *Schema, v interface{}, path string) {
+	if len(s.AnyOf) > 0 {
+		types := make([]string, 0, len(s.AnyOf))
+		var sameType ValidationErrors
+
+		for _, sub := range s.AnyOf {
+			subvd := &validator{file: vd.file, positions: vd.positions}
+			subvd.validate(sub, v, path)
+			if len(subvd.errs) == 0 {
+				return
+			}
+			if describeValueType(v) == describeType(sub) {
+				sameType = subvd.errs
+			}
+			types = append(types, describeType(sub))
+		}
+
+		// the errors of the schema of the same type are more helpful
+		if sameType != nil {
+			vd.errs = append(vd.errs, sameType...)
+			return
+		}
+		vd.errorf(path, "must be %s but %s", strings.Join(types, " or "), describeValue(v))
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		if v == nil {
+			return
+		}
+		m, ok := v.(map[interface{}]interface{})
+		if !ok {
+			vd.errorf(path, "must be %s but %s", describeType(s), describeValue(v))
+			return
+		}
+		vd.validateObject(s, m, path)
+	case "array":
+		if v == nil {
+			return
+		}
+		vs, ok := v.([]interface{})
+		if !ok {
+			vd.errorf(path, "must be %s but %s", describeType(s), describeValue(v))
+			return
+		}
+		for i, e := range vs {
+			vd.validate(s.Items, e, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			vd.errorf(path, "must be %s but %s", describeType(s), describeValue(v))
+			return
+		}
+		if len(s.Enum) > 0 && !inEnum(s.Enum, str) {
+			vd.errorf(path, "must be one of %s but %q", strings.Join(s.Enum, ", "), str)
+		}
+		if s.glob {
+			if _, err := CompileGlob(strings.TrimPrefix(str, "!")); err != nil {
+				vd.errorf(path, "%v", err)
+			}
+		}
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			if s.patternName != "" {
+				vd.errorf(path, "must be %s but %q", s.patternName, str)
+			} else {
+				vd.errorf(path, "must match %s but %q", s.Pattern, str)
+			}
+		}
+	case "integer":
+		n, ok := v.(int)
+		if !ok {
+			vd.errorf(path, "must be %s but %s", describeType(s), describeValue(v))
+			return
+		}
+		if s.Minimum != nil && n < *s.Minimum {
+			vd.errorf(path, "must be greater than or equal to %d but %d", *s.Minimum, n)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			vd.errorf(path, "must be less than or equal to %d but %d", *s.Maximum, n)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			vd.errorf(path, "must be %s but %s", describeType(s), describeValue(v))
+		}
+	}
+}
+
+func (vd *validator) validateObject(s *Schema, m map[interface{}]interface{}, path string) {
+	keys := make([]string, 0, len(m))
+	values := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		key := fmt.Sprintf("%v", k)
+		keys = append(keys, key)
+		values[key] = v
+	}
+	// report errors in the order of keys to make them stable
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		p := joinPath(path, key)
+
+		prop, ok := s.Properties[key]
+		if ok {
+			vd.validate(prop, values[key], p)
+			continue
+		}
+
+		if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			keyName := s.keyName
+			if keyName == "" {
+				keyName = "key"
+			}
+
+			msg := fmt.Sprintf("unknown %s %q", keyName, key)
+			if suggestion := suggest(key, s.Properties); suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+			}
+			if path != "" {
+				msg = path + ": " + msg
+			}
+			vd.errs = append(vd.errs, &ValidationError{
+				File:     vd.file,
+				Position: vd.positions.lookup(p),
+				Message:  msg,
+			})
+		}
+	}
+}
+
+func inEnum(enum []string, s string) bool {
+	for _, e := range enum {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func describeType(s *Schema) string {
+	switch s.Type {
+	case "object":
+		return "a map"
+	case "array":
+		return "an array"
+	case "integer":
+		return "an integer"
+	case "boolean":
+		return "a boolean"
+	case "string":
+		return "a string"
+	}
+	return s.Type
+}
+
+func describeValueType(v interface{}) string {
+	switch v.(type) {
+	case map[interface{}]interface{}:
+		return "a map"
+	case []interface{}:
+		return "an array"
+	case int:
+		return "an integer"
+	case bool:
+		return "a boolean"
+	case string:
+		return "a string"
+	}
+	return ""
+}
+
+func describeValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return fmt.Sprintf("boolean %v", v)
+	case int, int64, uint64:
+		return fmt.Sprintf("integer %v", v)
+	case float64:
+		return fmt.Sprintf("number %v", v)
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case []interface{}:
+		return "an array"
+	case map[interface{}]interface{}:
+		return "a map"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// suggest returns the most similar property name to the key for typos.
+func suggest(key string, props map[string]*Schema) string {
+	best := ""
+	bestDist := len(key) / 4
+	if bestDist < 1 {
+		bestDist = 1
+	}
+
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if d := levenshtein(key, name); d <= bestDist && (best == "" || d < levenshtein(key, best)) {
+			best = name
+		}
+	}
+
+	return best
+}
+
+// levenshtein returns the edit distance between a and b, where transposing
+// two adjacent characters is also an edit.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
+
+func minInt(ns ...int) int {
+	m := ns[0]
+	for _, n := range ns[1:] {
+		if n < m {
+			m = n
+		}
+	}
+	return m
+}
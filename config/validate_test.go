@@ -0,0 +1,199 @@
+package config
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/synchro-food/filelint/filetype"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{
+			src: `
+files:
+  include: ['**/*']
+  max-file-size: 10MB
+targets:
+  - patterns: ['**/*']
+    rules:
+      linebreak: {enforce: true, style: crlf}
+      no-bom:
+`,
+			want: "",
+		},
+		{
+			src: `
+targets:
+  - patterns: ['**/*']
+    rules:
+      linebreak: {enforce: true, style: CRLF}
+`,
+			want: `.filelint.yml:5:34: targets[0].rules.linebreak.style: must be one of lf, crlf but "CRLF"`,
+		},
+		{
+			src: `
+targets:
+  - patterns: ['**/*']
+    rules:
+      final-newline:
+        enfroce: true
+`,
+			want: `.filelint.yml:6:9: targets[0].rules.final-newline: unknown option "enfroce" (did you mean "enforce"?)`,
+		},
+		{
+			src: `
+targets:
+  - patterns: ['**/*']
+rules:
+  no-bom:
+    enforce: true
+`,
+			want: `.filelint.yml:4:1: unknown key "rules"`,
+		},
+		{
+			src: `
+targets:
+  - patterns: ['**/*', '!**/*.md']
+    excludePatterns:
+      - '**/*.{go'
+    rules:
+      no-bom: {enforce: true}
+`,
+			want: `.filelint.yml:5:7: targets[0].excludePatterns[0]: invalid glob pattern "**/*.{go": unclosed '{'`,
+		},
+		{
+			src: `
+targets:
+  - patterns: ['**/*']
+    rules:
+      no-boms: {enforce: true}
+`,
+			want: `.filelint.yml:5:7: targets[0].rules: unknown rule "no-boms" (did you mean "no-bom"?)`,
+		},
+		{
+			src: `
+targets:
+  - rules:
+      final-newline: {enforce: true, num: two}
+      first-newline: {enforce: true, num: -1}
+      linebreak: {enforce: 1, style: cr}
+`,
+			want: `.filelint.yml:4:38: targets[0].rules.final-newline.num: must be an integer but string "two"
+.filelint.yml:5:38: targets[0].rules.first-newline.num: must be greater than or equal to 0 but -1
+.filelint.yml:6:19: targets[0].rules.linebreak.enforce: must be a boolean but integer 1
+.filelint.yml:6:31: targets[0].rules.linebreak.style: must be one of lf, crlf but "cr"`,
+		},
+		{
+			src: `
+files:
+  exclude: 'vendor'
+  max-file-size: 10XB
+  large-file: ignore
+`,
+			want: `.filelint.yml:3:3: files.exclude: must be an array but string "vendor"
+.filelint.yml:4:3: files.max-file-size: must be a size such as "100KB" or "10MB" but "10XB"
+.filelint.yml:5:3: files.large-file: must be one of skip, warn, stream but "ignore"`,
+		},
+		{
+			src: `
+targets:
+  - types: [makefile, bash]
+    rules:
+      indent: {style: tab}
+`,
+			want: `.filelint.yml:3:23: targets[0].types[1]: must be one of ` + strings.Join(filetype.Names(), ", ") + ` but "bash"`,
+		},
+		{
+			src: `
+targets:
+  - patterns: ['**/*']
+    rules:
+      no-secrets: {detectors: [aws, gitlab]}
+`,
+			want: `.filelint.yml:5:37: targets[0].rules.no-secrets.detectors[1]: must be one of aws, private-key, github, slack, entropy but "gitlab"`,
+		},
+		{
+			src: `
+targets:
+  - patterns: ['**/*'
+`,
+			want: `.filelint.yml:3: did not find expected ',' or ']'`,
+		},
+	}
+
+	for _, tt := range tests {
+		err := Validate(".filelint.yml", []byte(tt.src))
+		if tt.want == "" {
+			assert.NoError(t, err, tt.src)
+			continue
+		}
+		if assert.Error(t, err, tt.src) {
+			assert.Equal(t, tt.want, err.Error())
+		}
+	}
+}
+
+func TestValidate_DefaultConfig(t *testing.T) {
+	src, err := configDefaultYmlBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, Validate("default.yml", src))
+
+	src, err = ioutil.ReadFile("../.filelint.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, Validate(".filelint.yml", src))
+}
+
+func TestValidateRules(t *testing.T) {
+	assert.NoError(t, ValidateRules("--rule", []byte("{no-bom: {enforce: true}}")))
+	assert.EqualError(t,
+		ValidateRules("--rule", []byte("{no-bom: {enforce: 1}}")),
+		"--rule:1:11: no-bom.enforce: must be a boolean but integer 1",
+	)
+}
+
+func TestLocate(t *testing.T) {
+	src := `files:
+  include:
+    - 'a'
+    - "b"
+targets:
+- patterns: [a, 'b, c']
+  rules:
+    "no-bom": {enforce: true}
+    linebreak:
+      style: |
+        lf
+      enforce: true # comment
+`
+	want := positions{
+		"files":                              {1, 1},
+		"files.include":                      {2, 3},
+		"files.include[0]":                   {3, 5},
+		"files.include[1]":                   {4, 5},
+		"targets":                            {5, 1},
+		"targets[0]":                         {6, 1},
+		"targets[0].patterns":                {6, 3},
+		"targets[0].patterns[0]":             {6, 14},
+		"targets[0].patterns[1]":             {6, 17},
+		"targets[0].rules":                   {7, 3},
+		"targets[0].rules.no-bom":            {8, 5},
+		"targets[0].rules.no-bom.enforce":    {8, 16},
+		"targets[0].rules.linebreak":         {9, 5},
+		"targets[0].rules.linebreak.style":   {10, 7},
+		"targets[0].rules.linebreak.enforce": {12, 7},
+	}
+
+	assert.Equal(t, want, locate([]byte(src)))
+	assert.Equal(t, Position{6, 3}, want.lookup("targets[0].patterns[2]"))
+}
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/synchro-food/filelint/lib"
+)
+
+// IgnoreFunc reports whether the path should be skipped while walking.
+type IgnoreFunc func(path string, isDir bool) bool
+
+// Walk walks the file tree once and calls fn with each text file which is
+// included and not excluded, as soon as it is found.
+// Directories excluded by `dir/**/*` style patterns or by ignore are pruned
+// without descending into them, and so are the directories which no include
+// patterns can match.
+func (f File) Walk(ignore IgnoreFunc, fn func(path string) error) error {
+	includes := cleanPatterns(addGlobSignIfDir(f.Include...))
+	excludes := cleanPatterns(addGlobSignIfDir(f.Exclude...))
+
+	includeGlobs, err := compileGlobs(includes)
+	if err != nil {
+		return err
+	}
+	excludeGlobs, err := compileGlobs(excludes)
+	if err != nil {
+		return err
+	}
+	pruneGlobs, err := compileGlobs(prunePatterns(excludes))
+	if err != nil {
+		return err
+	}
+
+	// the tree is walked from the common base of the roots of the include
+	// patterns, unless the roots are in different forms such as absolute
+	// and relative paths
+	roots := walkRoots(includes)
+	bases := roots
+	if base, ok := commonBase(roots); ok {
+		bases = []string{base}
+	}
+
+	for _, base := range bases {
+		err := filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if path == base && os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			if info.IsDir() {
+				if path == "." {
+					return nil
+				}
+				if !onRoots(path, roots) || matchAny(path, pruneGlobs) || (ignore != nil && ignore(path, true)) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if !matchAny(path, includeGlobs) || matchAny(path, excludeGlobs) {
+				return nil
+			}
+			if ignore != nil && ignore(path, false) {
+				return nil
+			}
+			if lib.IsBinary(path) {
+				return nil
+			}
+
+			return fn(path)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Explain returns the include pattern and the exclude pattern matching the
+// path, or empty strings if no patterns match. The path is a lint target if it
+// is included and not excluded.
+func (f File) Explain(path string) (include, exclude string, err error) {
+	path = filepath.Clean(path)
+
+	for _, p := range f.Include {
+		ok, err := matchFilePattern(path, p)
+		if err != nil {
+			return "", "", err
+		}
+		if ok {
+			include = p
+			break
+		}
+	}
+
+	for _, p := range f.Exclude {
+		ok, err := matchFilePattern(path, p)
+		if err != nil {
+			return "", "", err
+		}
+		if ok {
+			exclude = p
+			break
+		}
+	}
+
+	return include, exclude, nil
+}
+
+func matchFilePattern(path, pattern string) (bool, error) {
+	for _, p := range cleanPatterns(addGlobSignIfDir(pattern)) {
+		g, err := CompileGlob(p)
+		if err != nil {
+			return false, err
+		}
+		if g.Match(path) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func cleanPatterns(patterns []string) []string {
+	cleaned := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		cleaned = append(cleaned, filepath.Clean(p))
+	}
+	return cleaned
+}
+
+// prunePatterns returns the patterns matching the directories whose all
+// descendants are excluded by the `dir/**/*` style patterns.
+func prunePatterns(excludes []string) []string {
+	suffix := string(filepath.Separator) + filepath.Join("**", "*")
+	prunes := make([]string, 0, len(excludes))
+
+	for _, ex := range excludes {
+		if strings.HasSuffix(ex, suffix) {
+			prunes = append(prunes, strings.TrimSuffix(ex, suffix))
+		}
+	}
+
+	return prunes
+}
+
+// globBase returns the longest leading directory of the pattern which has no
+// glob meta characters. It is the root directory to walk.
+func globBase(pattern string) string {
+	sep := string(filepath.Separator)
+	elems := strings.Split(pattern, sep)
+
+	i := 0
+	for ; i < len(elems); i++ {
+		if strings.ContainsAny(elems[i], "*?[{") {
+			break
+		}
+	}
+
+	base := strings.Join(elems[:i], sep)
+	if base == "" {
+		if strings.HasPrefix(pattern, sep) {
+			return sep
+		}
+		return "."
+	}
+
+	return base
+}
+
+// walkRoots returns the roots to walk of the patterns, which don't contain
+// each other.
+func walkRoots(patterns []string) []string {
+	bases := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		bases = append(bases, globBase(p))
+	}
+	sort.Strings(bases)
+
+	var roots []string
+	for _, base := range bases {
+		contained := false
+		for _, root := range roots {
+			if within(base, root) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			roots = append(roots, base)
+		}
+	}
+	return roots
+}
+
+// commonBase returns the deepest directory containing all roots, from which
+// the walk finds the paths in the same form as the roots. ok is false if
+// there is no such directory, such as for absolute and relative paths.
+func commonBase(roots []string) (base string, ok bool) {
+	if len(roots) == 0 {
+		return "", false
+	}
+	if len(roots) == 1 {
+		return roots[0], true
+	}
+
+	sep := string(filepath.Separator)
+	abs := filepath.IsAbs(roots[0])
+	common := strings.Split(roots[0], sep)
+	for _, root := range roots[1:] {
+		if filepath.IsAbs(root) != abs {
+			return "", false
+		}
+		elems := strings.Split(root, sep)
+		n := 0
+		for n < len(common) && n < len(elems) && common[n] == elems[n] {
+			n++
+		}
+		common = common[:n]
+	}
+
+	// the walk can't go up to the parents
+	for _, root := range roots {
+		for _, elem := range strings.Split(root, sep)[len(common):] {
+			if elem == ".." {
+				return "", false
+			}
+		}
+	}
+
+	base = strings.Join(common, sep)
+	switch {
+	case base == "" && abs:
+		return sep, true
+	case base == "":
+		return ".", true
+	}
+	return base, true
+}
+
+// onRoots reports whether the directory is one of the roots, or contains or
+// is contained by one of them.
+func onRoots(dir string, roots []string) bool {
+	for _, root := range roots {
+		if within(dir, root) || within(root, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// within reports whether the path is the directory dir or is under it.
+func within(path, dir string) bool {
+	if path == dir || dir == "." {
+		return true
+	}
+	sep := string(filepath.Separator)
+	if !strings.HasSuffix(dir, sep) {
+		dir += sep
+	}
+	return strings.HasPrefix(path, dir)
+}
+
+func compileGlobs(patterns []string) ([]*Glob, error) {
+	globs := make([]*Glob, 0, len(patterns))
+	for _, p := range patterns {
+		g, err := CompileGlob(p)
+		if err != nil {
+			return nil, err
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+func matchAny(path string, globs []*Glob) bool {
+	for _, g := range globs {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTree(t *testing.T, files map[string]string) (dir string, cleanup func()) {
+	dir, err := ioutil.TempDir("", "filelint-walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFile_Walk(t *testing.T) {
+	_, cleanup := setupTree(t, map[string]string{
+		"a.txt":                    "a",
+		"b.pdf":                    "b",
+		"bin/c":                    "\x00\x01",
+		"src/d.go":                 "d",
+		"src/e.md":                 "e",
+		"node_modules/f/g.js":      "g",
+		"src/node_modules/h/i.js":  "i",
+		"vendor/j.go":              "j",
+		"vendor/k/l.go":            "l",
+		"ignored/m.txt":            "m",
+		"src/ignored.txt":          "n",
+		"docs/node_modules.txt":    "o",
+		"docs/node_modules/p.text": "p",
+	})
+	defer cleanup()
+
+	tests := []struct {
+		file   File
+		want   []string
+		pruned []string
+	}{
+		{
+			file: File{
+				Include: []string{"./**/*"},
+				Exclude: []string{"**/*.pdf", "**/node_modules/**/*", "vendor/*"},
+			},
+			want: []string{
+				"a.txt",
+				"docs/node_modules.txt",
+				"src/d.go",
+				"src/e.md",
+				"vendor/k/l.go",
+			},
+			pruned: []string{"node_modules/f", "src/node_modules/h", "docs/node_modules"},
+		},
+		{
+			file: File{
+				Include: []string{"src", "a.txt", "src/*.go", "nonexist"},
+			},
+			want: []string{
+				"a.txt",
+				"src/d.go",
+				"src/e.md",
+				"src/node_modules/h/i.js",
+			},
+		},
+		{
+			file: File{
+				Include: []string{"**/*.js"},
+				Exclude: []string{"src/node_modules"},
+			},
+			want: []string{
+				"node_modules/f/g.js",
+			},
+			pruned: []string{"src/node_modules/h"},
+		},
+		{
+			// the directories which no include patterns can match are pruned
+			file: File{
+				Include: []string{"src/*.go", "vendor/k/*.go", "docs/*.txt"},
+			},
+			want: []string{
+				"docs/node_modules.txt",
+				"src/d.go",
+				"vendor/k/l.go",
+			},
+			pruned: []string{"bin", "node_modules", "ignored"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := []string{}
+		walkedDirs := []string{}
+
+		err := tt.file.Walk(func(path string, isDir bool) bool {
+			if isDir {
+				walkedDirs = append(walkedDirs, filepath.ToSlash(path))
+			}
+			return filepath.Base(path) == "ignored" || filepath.Base(path) == "ignored.txt"
+		}, func(path string) error {
+			got = append(got, filepath.ToSlash(path))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sort.Strings(got)
+		assert.Equal(t, tt.want, got)
+
+		// excluded directories should not be descended
+		for _, dir := range tt.pruned {
+			assert.NotContains(t, walkedDirs, dir)
+		}
+
+		// the tree is walked once for all include patterns
+		walked := make(map[string]bool)
+		for _, dir := range walkedDirs {
+			assert.False(t, walked[dir], "%s is walked twice", dir)
+			walked[dir] = true
+		}
+	}
+}
+
+func TestFile_Explain(t *testing.T) {
+	_, cleanup := setupTree(t, map[string]string{
+		"a.txt":    "a",
+		"src/b.go": "b",
+		"src/c.md": "c",
+	})
+	defer cleanup()
+
+	f := File{
+		Include: []string{"*.go", "src", "**/*"},
+		Exclude: []string{"**/*.md", "src/*"},
+	}
+
+	tests := []struct {
+		path    string
+		include string
+		exclude string
+	}{
+		{"a.txt", "**/*", ""},
+		{"src/b.go", "src", "src/*"},
+		{"./src/c.md", "src", "**/*.md"},
+		{"nonexist/d", "**/*", ""},
+	}
+
+	for _, tt := range tests {
+		include, exclude, err := f.Explain(filepath.FromSlash(tt.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.include, include, tt.path)
+		assert.Equal(t, tt.exclude, exclude, tt.path)
+	}
+
+	_, _, err := File{Include: []string{"**/*.{go"}}.Explain("a.go")
+	assert.Error(t, err)
+}
+
+func TestCommonBase(t *testing.T) {
+	tests := []struct {
+		roots []string
+		want  string
+		ok    bool
+	}{
+		{[]string{"src"}, "src", true},
+		{[]string{"src/a", "src/b"}, "src", true},
+		{[]string{"a.txt", "src"}, ".", true},
+		{[]string{"../a", "../b"}, "..", true},
+		{[]string{"/a/b", "/a/c"}, "/a", true},
+		{[]string{"/a", "/b"}, "/", true},
+		{[]string{"../a", "b"}, "", false},
+		{[]string{"/a", "b"}, "", false},
+	}
+
+	for _, tt := range tests {
+		var roots []string
+		for _, r := range tt.roots {
+			roots = append(roots, filepath.FromSlash(r))
+		}
+		got, ok := commonBase(roots)
+		assert.Equal(t, filepath.FromSlash(tt.want), got, "%v", tt.roots)
+		assert.Equal(t, tt.ok, ok, "%v", tt.roots)
+	}
+}
@@ -2,14 +2,39 @@ package dispatcher
 
 import (
 	"fmt"
+	"sort"
 
-	gitignore "github.com/sabhiram/go-gitignore"
 	"github.com/synchro-food/filelint/config"
 	"github.com/synchro-food/filelint/lint"
 )
 
+// RuleError is an error of the rule configured for the file, such as an
+// undefined rule or an invalid option.
+type RuleError struct {
+	File string
+	Rule string
+
+	// Origin is where the rule is configured like
+	// ".filelint.yml:7:9 targets[1].rules.indent", or empty if it is unknown
+	Origin string
+
+	Err error
+}
+
+func (e *RuleError) Error() string {
+	if e.Origin == "" {
+		return fmt.Sprintf("%v (applied to %s)", e.Err, e.File)
+	}
+	return fmt.Sprintf("%s: %v (applied to %s)", e.Origin, e.Err, e.File)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
 type Dispatcher struct {
-	config *config.Config
+	config  *config.Config
+	ignores []config.IgnoreFunc
 }
 
 func NewDispatcher(cfg *config.Config) *Dispatcher {
@@ -18,62 +43,80 @@ func NewDispatcher(cfg *config.Config) *Dispatcher {
 	}
 }
 
+// AddIgnore adds the function to skip files, such as .gitignore and
+// .filelintignore. A file is skipped if any of the functions returns true.
+func (dp *Dispatcher) AddIgnore(ignore config.IgnoreFunc) {
+	dp.ignores = append(dp.ignores, ignore)
+}
+
+// Dispatch walks the target files and calls onDipatched with each file as
+// soon as it is found, with its type, the enforced rules and all the rules
+// matched by the file. The type is detected once here for all of them.
 func (dp *Dispatcher) Dispatch(
-	gitignorePath string,
-	onDipatched func(file string, rules []lint.Rule) error,
+	onDipatched func(file, typ string, rules []lint.Rule, matched config.RuleMap) error,
 ) error {
-	files, err := dp.config.File.FindTargets()
-	if err != nil {
-		return err
-	}
-
-	if gitignorePath != "" {
-		files, err = excludeFilesWithGitIgnore(files, gitignorePath)
+	return dp.config.File.Walk(dp.ignore, func(file string) error {
+		typ, _, err := config.DetectType(file)
 		if err != nil {
 			return err
 		}
-	}
-
-	for _, file := range files {
-		definedRules := lint.GetDefinedRules()
-		rules := make([]lint.Rule, 0, definedRules.Size())
-		userRules := dp.config.MatchedRule(file)
-
-		for ruleName, options := range userRules {
-			if !definedRules.Has(ruleName) {
-				return fmt.Errorf("%s is undefined", ruleName)
-			}
-			if options["enforce"] != true {
-				continue
-			}
-			rule, err := definedRules.Get(ruleName).New(options)
-			if err != nil {
-				return err
-			}
-			rules = append(rules, rule)
+		matched, err := dp.config.MatchedRule(file, typ)
+		if err != nil {
+			return err
 		}
-
-		if err := onDipatched(file, rules); err != nil {
+		rules, err := dp.rules(file, typ, matched)
+		if err != nil {
 			return err
 		}
-	}
+		return onDipatched(file, typ, rules, matched)
+	})
+}
 
-	return nil
+// Targets returns the sorted target files which Dispatch walks.
+func (dp *Dispatcher) Targets() ([]string, error) {
+	return dp.config.File.FindTargets(dp.ignore)
 }
 
-func excludeFilesWithGitIgnore(files []string, gitignorePath string) ([]string, error) {
-	gi, err := gitignore.CompileIgnoreFile(gitignorePath)
-	if err != nil {
-		return nil, err
+func (dp *Dispatcher) ignore(path string, isDir bool) bool {
+	for _, ignore := range dp.ignores {
+		if ignore(path, isDir) {
+			return true
+		}
 	}
+	return false
+}
 
-	newFiles := make([]string, 0, len(files))
+func (dp *Dispatcher) rules(file, typ string, userRules config.RuleMap) ([]lint.Rule, error) {
+	definedRules := lint.GetDefinedRules()
+	rules := make([]lint.Rule, 0, definedRules.Size())
 
-	for _, f := range files {
-		if !gi.MatchesPath(f) {
-			newFiles = append(newFiles, f)
+	// the rules are created in the order of their names, so that the errors
+	// are the same in every run
+	names := make([]string, 0, len(userRules))
+	for ruleName := range userRules {
+		names = append(names, ruleName)
+	}
+	sort.Strings(names)
+
+	for _, ruleName := range names {
+		options := userRules[ruleName]
+		if !definedRules.Has(ruleName) {
+			return nil, dp.ruleError(file, typ, ruleName, fmt.Errorf("%s is undefined", ruleName))
 		}
+		if options["enforce"] != true {
+			continue
+		}
+		rule, err := definedRules.Get(ruleName).New(options)
+		if err != nil {
+			return nil, dp.ruleError(file, typ, ruleName, err)
+		}
+		rules = append(rules, rule)
 	}
 
-	return newFiles, nil
+	return rules, nil
+}
+
+func (dp *Dispatcher) ruleError(file, typ, rule string, err error) error {
+	origin, _ := dp.config.RuleOrigin(file, typ, rule)
+	return &RuleError{File: file, Rule: rule, Origin: origin, Err: err}
 }
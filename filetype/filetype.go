@@ -0,0 +1,372 @@
+// Package filetype detects the types of files such as "shell" and "makefile"
+// from their names and contents.
+package filetype
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Type is a file type.
+type Type struct {
+	Name string
+
+	// Filenames is the patterns of the base names like "Makefile" and
+	// "Dockerfile.*"
+	Filenames []string
+
+	// Extensions is the extensions including the dot like ".sh"
+	Extensions []string
+
+	// Interpreters is the commands in the shebang line like "bash"
+	Interpreters []string
+
+	// Aliases is the other names used in modelines like "sh"
+	Aliases []string
+}
+
+// Method is how the type of a file is detected.
+type Method string
+
+const (
+	ByModeline  Method = "modeline"
+	ByFilename  Method = "filename"
+	ByShebang   Method = "shebang"
+	ByExtension Method = "extension"
+)
+
+var types = []*Type{
+	{
+		Name:         "shell",
+		Filenames:    []string{".bashrc", ".bash_profile", ".bash_logout", ".profile", ".zshrc", ".zshenv", ".zprofile", ".envrc"},
+		Extensions:   []string{".sh", ".bash", ".zsh", ".ksh"},
+		Interpreters: []string{"sh", "bash", "zsh", "ksh", "dash", "ash"},
+		Aliases:      []string{"sh", "bash", "zsh", "ksh", "shell-script"},
+	},
+	{
+		Name:         "makefile",
+		Filenames:    []string{"Makefile", "makefile", "GNUmakefile"},
+		Extensions:   []string{".mk", ".mak"},
+		Interpreters: []string{"make"},
+		Aliases:      []string{"make"},
+	},
+	{
+		Name:       "dockerfile",
+		Filenames:  []string{"Dockerfile", "Dockerfile.*", "Containerfile"},
+		Extensions: []string{".dockerfile"},
+		Aliases:    []string{"docker"},
+	},
+	{
+		Name:       "markdown",
+		Extensions: []string{".md", ".markdown", ".mkd", ".mdown"},
+		Aliases:    []string{"md", "gfm"},
+	},
+	{
+		Name:       "yaml",
+		Extensions: []string{".yml", ".yaml"},
+		Aliases:    []string{"yml"},
+	},
+	{
+		Name:       "json",
+		Extensions: []string{".json"},
+	},
+	{
+		Name:       "toml",
+		Extensions: []string{".toml"},
+	},
+	{
+		Name:       "go",
+		Extensions: []string{".go"},
+		Aliases:    []string{"golang"},
+	},
+	{
+		Name:         "python",
+		Extensions:   []string{".py"},
+		Interpreters: []string{"python"},
+		Aliases:      []string{"py"},
+	},
+	{
+		Name:         "ruby",
+		Filenames:    []string{"Gemfile", "Rakefile"},
+		Extensions:   []string{".rb"},
+		Interpreters: []string{"ruby"},
+		Aliases:      []string{"rb"},
+	},
+	{
+		Name:         "perl",
+		Extensions:   []string{".pl", ".pm"},
+		Interpreters: []string{"perl"},
+	},
+	{
+		Name:         "javascript",
+		Extensions:   []string{".js", ".mjs", ".cjs"},
+		Interpreters: []string{"node"},
+		Aliases:      []string{"js"},
+	},
+	{
+		Name:       "typescript",
+		Extensions: []string{".ts"},
+		Aliases:    []string{"ts"},
+	},
+	{
+		Name:       "html",
+		Extensions: []string{".html", ".htm"},
+	},
+	{
+		Name:       "css",
+		Extensions: []string{".css"},
+	},
+	{
+		Name:       "text",
+		Extensions: []string{".txt"},
+		Aliases:    []string{"txt"},
+	},
+}
+
+// Names returns the names of all types in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the type of the name or the alias, or nil if it is unknown.
+func Lookup(name string) *Type {
+	name = strings.ToLower(name)
+	for _, t := range types {
+		if t.Name == name || contains(t.Aliases, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+// headSize and tailSize are the sizes of the contents read for detection.
+// The shebang and modelines are at most a few lines from the top or the
+// bottom of files.
+const (
+	headSize = 1024
+	tailSize = 1024
+)
+
+// Detect returns the name of the type of the file and how it is detected.
+// The name is empty if the type is unknown.
+// A file which doesn't exist is detected only from its name.
+func Detect(file string) (string, Method, error) {
+	head, tail, err := readHeadTail(file)
+	if err != nil && !os.IsNotExist(err) {
+		return "", "", err
+	}
+	name, by := detect(file, head, tail)
+	return name, by, nil
+}
+
+// DetectSource returns the name of the type of the file having the content
+// src and how it is detected.
+func DetectSource(file string, src []byte) (string, Method) {
+	head, tail := src, src
+	if len(head) > headSize {
+		head = head[:headSize]
+	}
+	if len(tail) > tailSize {
+		tail = tail[len(tail)-tailSize:]
+	}
+	return detect(file, head, tail)
+}
+
+// detect detects the type in the order of the reliability: modelines are
+// written explicitly, filenames like "Makefile" are conventions, and shebangs
+// are more accurate than extensions for scripts.
+func detect(file string, head, tail []byte) (string, Method) {
+	if t := byModeline(head, tail); t != nil {
+		return t.Name, ByModeline
+	}
+
+	base := filepath.Base(file)
+	for _, t := range types {
+		for _, pattern := range t.Filenames {
+			if ok, _ := path.Match(pattern, base); ok {
+				return t.Name, ByFilename
+			}
+		}
+	}
+
+	if t := byShebang(head); t != nil {
+		return t.Name, ByShebang
+	}
+
+	ext := strings.ToLower(filepath.Ext(base))
+	if ext != "" && ext != base {
+		for _, t := range types {
+			if contains(t.Extensions, ext) {
+				return t.Name, ByExtension
+			}
+		}
+	}
+
+	return "", ""
+}
+
+func byShebang(head []byte) *Type {
+	if !bytes.HasPrefix(head, []byte("#!")) {
+		return nil
+	}
+	line := string(firstLines(head, 1)[0][2:])
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	cmd := path.Base(fields[0])
+
+	// "#!/usr/bin/env -S VAR=value bash -e"
+	if cmd == "env" {
+		cmd = ""
+		for _, f := range fields[1:] {
+			if strings.HasPrefix(f, "-") || strings.Contains(f, "=") {
+				continue
+			}
+			cmd = path.Base(f)
+			break
+		}
+	}
+
+	// "python3.8" is "python"
+	cmd = strings.TrimRight(cmd, "0123456789.")
+
+	for _, t := range types {
+		if contains(t.Interpreters, cmd) {
+			return t
+		}
+	}
+	return nil
+}
+
+// numModelines is the number of the lines from the top and the bottom where
+// vim reads modelines.
+const numModelines = 5
+
+var (
+	vimModeline   = regexp.MustCompile(`(?:^|\s)(?:vi|vim|ex):\s*(?:se(?:t)?\s+)?(?:.*[\s:])?(?:ft|filetype|syntax)=([\w.+-]+)`)
+	emacsModeline = regexp.MustCompile(`-\*-(.+?)-\*-`)
+	emacsMode     = regexp.MustCompile(`(?i)(?:^|;)\s*mode:\s*([\w+-]+)`)
+)
+
+func byModeline(head, tail []byte) *Type {
+	// the emacs modeline is on the first line, or on the second line if the
+	// first line is a shebang
+	lines := firstLines(head, 2)
+	if !bytes.HasPrefix(lines[0], []byte("#!")) {
+		lines = lines[:1]
+	}
+	for _, l := range lines {
+		if t := byEmacsModeline(string(l)); t != nil {
+			return t
+		}
+	}
+
+	candidates := append(firstLines(head, numModelines), lastLines(tail, numModelines)...)
+	for _, l := range candidates {
+		if m := vimModeline.FindSubmatch(l); m != nil {
+			if t := Lookup(string(m[1])); t != nil {
+				return t
+			}
+		}
+	}
+
+	return nil
+}
+
+func byEmacsModeline(line string) *Type {
+	m := emacsModeline.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+
+	mode := strings.TrimSpace(m[1])
+	if strings.Contains(mode, ":") {
+		mm := emacsMode.FindStringSubmatch(mode)
+		if mm == nil {
+			return nil
+		}
+		mode = mm[1]
+	}
+	mode = strings.TrimSuffix(strings.ToLower(mode), "-mode")
+
+	if t := Lookup(mode); t != nil {
+		return t
+	}
+	// variants like "makefile-gmake"
+	if i := strings.Index(mode, "-"); i > 0 {
+		return Lookup(mode[:i])
+	}
+	return nil
+}
+
+func firstLines(src []byte, n int) [][]byte {
+	lines := bytes.SplitN(src, []byte("\n"), n+1)
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	return lines
+}
+
+func lastLines(src []byte, n int) [][]byte {
+	lines := bytes.Split(bytes.TrimRight(src, "\r\n"), []byte("\n"))
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+func readHeadTail(file string) (head, tail []byte, err error) {
+	fp, err := os.Open(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fp.Close()
+
+	head = make([]byte, headSize)
+	n, err := io.ReadFull(fp, head)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// the whole file is read
+		return head[:n], head[:n], nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fi, err := fp.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	offset := fi.Size() - tailSize
+	if offset < 0 {
+		offset = 0
+	}
+	tail = make([]byte, tailSize)
+	n, err = fp.ReadAt(tail, offset)
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+
+	return head, tail[:n], nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package filetype
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectSource(t *testing.T) {
+	tests := []struct {
+		file string
+		src  string
+		want string
+		by   Method
+	}{
+		{"Makefile", "all:\n\techo\n", "makefile", ByFilename},
+		{"src/GNUmakefile", "", "makefile", ByFilename},
+		{"rules.mk", "", "makefile", ByExtension},
+		{"Dockerfile.dev", "FROM scratch\n", "dockerfile", ByFilename},
+		{".envrc", "export A=1\n", "shell", ByFilename},
+		{"bin/deploy", "#!/bin/bash\nset -e\n", "shell", ByShebang},
+		{"bin/run", "#!/usr/bin/env -S LANG=C python3.8 -u\n", "python", ByShebang},
+		{"bin/serve", "#!/usr/bin/env node\n", "javascript", ByShebang},
+		{"a.sh", "#!/usr/bin/env zsh\n", "shell", ByShebang},
+		{"README.md", "# title\n", "markdown", ByExtension},
+		{"A.YML", "a: 1\n", "yaml", ByExtension},
+		{"build", "# -*- mode: makefile-gmake; coding: utf-8 -*-\nall:\n", "makefile", ByModeline},
+		{"script", "#!/bin/sh\n# -*- python -*-\n", "python", ByModeline},
+		{"notes", "text\n\n# vim: set ts=8 ft=markdown:\n", "markdown", ByModeline},
+		{"a.txt", "# vim:noet:filetype=make\n", "makefile", ByModeline},
+		{"a.txt", "vim: ft=unknown\n", "text", ByExtension},
+		{".gitignore", "*.o\n", "", ""},
+		{"bin/unknown", "#!/usr/bin/awk -f\n", "", ""},
+		{".txt", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		got, by := DetectSource(tt.file, []byte(tt.src))
+		assert.Equal(t, tt.want, got, tt.file)
+		assert.Equal(t, tt.by, by, tt.file)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filelint-filetype")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the modeline at the bottom of a large file
+	file := filepath.Join(dir, "large")
+	src := strings.Repeat("text\n", 1000) + "vim: ft=sh\n"
+	if err := ioutil.WriteFile(file, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, by, err := Detect(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "shell", got)
+	assert.Equal(t, ByModeline, by)
+
+	// a file which doesn't exist is detected by its name
+	got, by, err = Detect(filepath.Join(dir, "Makefile"))
+	assert.NoError(t, err)
+	assert.Equal(t, "makefile", got)
+	assert.Equal(t, ByFilename, by)
+}
+
+func TestLookup(t *testing.T) {
+	assert.Equal(t, "shell", Lookup("bash").Name)
+	assert.Equal(t, "makefile", Lookup("Make").Name)
+	assert.Nil(t, Lookup("cobol"))
+}
@@ -0,0 +1,36 @@
+package ignore
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/synchro-food/filelint/lib"
+)
+
+// FilelintIgnoreFile is the name of the files listing the paths which should
+// not be linted, in the gitignore syntax.
+const FilelintIgnoreFile = ".filelintignore"
+
+// FindFilelintIgnore returns the matcher following .filelintignore files in
+// the git repository containing the current directory, or in the current
+// directory if it is not in a git repository.
+func FindFilelintIgnore() (*Matcher, error) {
+	root, err := lib.FindGitRootPath(".")
+	if err != nil {
+		if err != lib.ErrNotGitRepository {
+			return nil, err
+		}
+		root = "."
+	}
+
+	return New(root, FilelintIgnoreFile)
+}
+
+// NewIgnoreFile returns the matcher following only the file.
+// The patterns are relative to the directory of the file.
+func NewIgnoreFile(file string) (*Matcher, error) {
+	if !lib.IsExist(file) {
+		return nil, fmt.Errorf("no such ignore file: %s", file)
+	}
+	return New(filepath.Dir(file), "", file)
+}
@@ -0,0 +1,80 @@
+package ignore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_FilelintIgnore(t *testing.T) {
+	root, err := ioutil.TempDir("", "filelint-ignore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	writeFiles(t, root, map[string]string{
+		".filelintignore":          "/vendor/\n*.golden\n",
+		"testdata/.filelintignore": "fixtures/\n!keep.golden\n",
+		"other.ignore":             "*.txt\n",
+	})
+
+	m, err := New(root, FilelintIgnoreFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"vendor", true, true},
+		{"vendor/a.go", false, true},
+		{"src/vendor", true, false},
+		{"a.golden", false, true},
+		{"testdata/a.golden", false, true},
+		{"testdata/keep.golden", false, false},
+		{"keep.golden", false, true},
+		{"testdata/fixtures/a.txt", false, true},
+		{"fixtures/a.txt", false, false},
+		{"a.txt", false, false},
+	}
+
+	for _, tt := range tests {
+		got := m.Ignore(filepath.Join(root, filepath.FromSlash(tt.path)), tt.isDir)
+		assert.Equal(t, tt.want, got, tt.path)
+	}
+
+	// patterns out of the root never match
+	assert.False(t, m.Ignore(filepath.Join(root, "..", "a.golden"), false))
+}
+
+func TestNewIgnoreFile(t *testing.T) {
+	root, err := ioutil.TempDir("", "filelint-ignore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	writeFiles(t, root, map[string]string{
+		".filelintignore": "*.golden\n",
+		"conf/ignore":     "*.txt\n/b.md\n",
+	})
+
+	m, err := NewIgnoreFile(filepath.Join(root, "conf", "ignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, m.Ignore(filepath.Join(root, "conf", "a.txt"), false))
+	assert.True(t, m.Ignore(filepath.Join(root, "conf", "b.md"), false))
+	assert.False(t, m.Ignore(filepath.Join(root, "b.md"), false))
+	assert.False(t, m.Ignore(filepath.Join(root, "conf", "a.golden"), false))
+
+	_, err = NewIgnoreFile(filepath.Join(root, "nonexist"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,202 @@
+package ignore
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/synchro-food/filelint/lib"
+)
+
+// NewGitIgnore returns the matcher following the ignore rules of the git
+// repository at root: .gitignore files in any directories,
+// $GIT_DIR/info/exclude and the file specified by core.excludesFile.
+// Nested repositories such as submodules follow their own rules.
+func NewGitIgnore(root string) (*Matcher, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	gitDir, err := findGitDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	if f, err := excludesFile(gitDir); err != nil {
+		return nil, err
+	} else if f != "" {
+		files = append(files, f)
+	}
+	files = append(files, filepath.Join(gitDir, "info", "exclude"))
+
+	m, err := New(root, ".gitignore", files...)
+	if err != nil {
+		return nil, err
+	}
+	m.newSub = NewGitIgnore
+
+	return m, nil
+}
+
+// FindGitIgnore returns the matcher of the git repository containing the
+// current directory, or nil if it is not in a git repository.
+func FindGitIgnore() (*Matcher, error) {
+	root, err := lib.FindGitRootPath(".")
+	if err != nil {
+		if err == lib.ErrNotGitRepository {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return NewGitIgnore(root)
+}
+
+// findGitDir returns $GIT_DIR of the repository at root.
+// .git may be a file pointing to the real directory like submodules.
+func findGitDir(root string) (string, error) {
+	dotGit := filepath.Join(root, ".git")
+	if lib.IsDir(dotGit) || !lib.IsExist(dotGit) {
+		return dotGit, nil
+	}
+
+	src, err := ioutil.ReadFile(dotGit)
+	if err != nil {
+		return "", err
+	}
+
+	const prefix = "gitdir:"
+	line := strings.TrimSpace(string(src))
+	if !strings.HasPrefix(line, prefix) {
+		return dotGit, nil
+	}
+
+	dir := strings.TrimSpace(strings.TrimPrefix(line, prefix))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+
+	return dir, nil
+}
+
+// excludesFile returns the path of core.excludesFile.
+// It is looked up in the system, global and repository config files in this
+// order, and the default is $XDG_CONFIG_HOME/git/ignore.
+func excludesFile(gitDir string) (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	xdgHome := os.Getenv("XDG_CONFIG_HOME")
+	if xdgHome == "" {
+		xdgHome = filepath.Join(home, ".config")
+	}
+
+	configs := []string{
+		"/etc/gitconfig",
+		filepath.Join(xdgHome, "git", "config"),
+		filepath.Join(home, ".gitconfig"),
+		filepath.Join(gitDir, "config"),
+	}
+	if f := os.Getenv("GIT_CONFIG_GLOBAL"); f != "" {
+		configs[1], configs[2] = "", f
+	}
+	if os.Getenv("GIT_CONFIG_NOSYSTEM") != "" {
+		configs[0] = ""
+	}
+
+	file := filepath.Join(xdgHome, "git", "ignore")
+	for _, c := range configs {
+		if c == "" {
+			continue
+		}
+		if v, ok, err := readGitConfig(c, "core", "excludesfile"); err != nil {
+			return "", err
+		} else if ok {
+			file = v
+		}
+	}
+
+	if file == "" {
+		return "", nil
+	}
+	return homedir.Expand(file)
+}
+
+// readGitConfig reads the value of section.key in the git config file.
+// It supports only the simple form of the config files.
+func readGitConfig(file, section, key string) (value string, ok bool, err error) {
+	fp, err := os.Open(file)
+	if err != nil {
+		if os.IsNotExist(err) || os.IsPermission(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	defer fp.Close()
+
+	var current string
+
+	sc := bufio.NewScanner(fp)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+
+		if strings.HasPrefix(line, "[") {
+			end := strings.Index(line, "]")
+			if end < 0 {
+				continue
+			}
+			current = strings.ToLower(strings.Fields(line[1:end] + " ")[0])
+			line = strings.TrimSpace(line[end+1:])
+		}
+
+		if current != section || line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || strings.ToLower(strings.TrimSpace(kv[0])) != key {
+			continue
+		}
+
+		value, ok = unquoteGitConfig(kv[1]), true
+	}
+
+	return value, ok, sc.Err()
+}
+
+func unquoteGitConfig(s string) string {
+	var buf strings.Builder
+	inQuote := false
+
+	s = strings.TrimSpace(s)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 't':
+				buf.WriteByte('\t')
+			case 'n':
+				buf.WriteByte('\n')
+			default:
+				buf.WriteByte(s[i])
+			}
+		case (c == '#' || c == ';') && !inQuote:
+			return strings.TrimSpace(buf.String())
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	return strings.TrimSpace(buf.String())
+}
@@ -0,0 +1,218 @@
+package ignore
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/synchro-food/filelint/lib"
+)
+
+// Matcher matches paths against ignore files in the same way as git:
+// the per-directory ignore files in deeper directories take precedence, the
+// last matching pattern decides the result, and a path is ignored if one of
+// its parent directories is ignored.
+type Matcher struct {
+	root string
+
+	// name is the name of the per-directory ignore files such as ".gitignore"
+	name string
+
+	// base is the patterns applied to the whole tree with lower precedence
+	// than the per-directory ignore files
+	base []*Pattern
+
+	// newSub returns the matcher for the nested repository at the directory,
+	// or nil if nested repositories are not separated
+	newSub func(dir string) (*Matcher, error)
+
+	mu   sync.Mutex
+	dirs map[string][]*Pattern
+	subs map[string]*Matcher
+
+	// lastDirs caches the results of the directories, since they are
+	// checked for every file inside them
+	lastDirs map[string]*Pattern
+}
+
+// New returns the matcher of the tree at root which reads per-directory ignore
+// files named name. The patterns in the files are applied to the whole tree
+// with the lowest precedence. If name is empty, only the files are used.
+func New(root, name string, files ...string) (*Matcher, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	m := newMatcher(root, name)
+
+	for _, f := range files {
+		ps, err := ReadPatterns(f, "")
+		if err != nil {
+			return nil, err
+		}
+		m.base = append(m.base, ps...)
+	}
+
+	return m, nil
+}
+
+func newMatcher(root, name string) *Matcher {
+	return &Matcher{
+		root:     root,
+		name:     name,
+		dirs:     make(map[string][]*Pattern),
+		subs:     make(map[string]*Matcher),
+		lastDirs: make(map[string]*Pattern),
+	}
+}
+
+// Root returns the absolute path of the root directory.
+func (m *Matcher) Root() string {
+	return m.root
+}
+
+// Ignore reports whether path is ignored. path is relative to the current
+// directory or absolute. The paths outside of the root are never ignored.
+func (m *Matcher) Ignore(path string, isDir bool) bool {
+	p, _ := m.Explain(path, isDir)
+	return p != nil && !p.negate
+}
+
+// Explain returns the pattern which decides whether path is ignored and the
+// path the pattern matched, which is path itself or one of its parent
+// directories. It returns nil if no patterns match.
+func (m *Matcher) Explain(path string, isDir bool) (*Pattern, string) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, ""
+	}
+
+	rel, err := filepath.Rel(m.root, abs)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return nil, ""
+	}
+
+	p, matched := m.explain(filepath.ToSlash(rel), isDir)
+	if matched != "" {
+		matched = filepath.Join(m.root, filepath.FromSlash(matched))
+	}
+	return p, matched
+}
+
+func (m *Matcher) explain(rel string, isDir bool) (*Pattern, string) {
+	elems := strings.Split(rel, "/")
+
+	for i := 1; i < len(elems); i++ {
+		dir := strings.Join(elems[:i], "/")
+
+		if p := m.lastDir(dir); p != nil && !p.negate {
+			return p, dir
+		}
+
+		sub, err := m.sub(dir)
+		if err != nil {
+			return nil, ""
+		}
+		if sub != nil {
+			p, matched := sub.explain(strings.Join(elems[i:], "/"), isDir)
+			if matched != "" {
+				matched = dir + "/" + matched
+			}
+			return p, matched
+		}
+	}
+
+	if p := m.last(rel, isDir); p != nil {
+		return p, rel
+	}
+	return nil, ""
+}
+
+func (m *Matcher) lastDir(dir string) *Pattern {
+	m.mu.Lock()
+	p, ok := m.lastDirs[dir]
+	m.mu.Unlock()
+	if ok {
+		return p
+	}
+
+	p = m.last(dir, true)
+
+	m.mu.Lock()
+	m.lastDirs[dir] = p
+	m.mu.Unlock()
+
+	return p
+}
+
+// last returns the last pattern matching rel, in the order of precedence.
+func (m *Matcher) last(rel string, isDir bool) *Pattern {
+	var last *Pattern
+
+	for _, p := range m.base {
+		if p.Match(rel, isDir) {
+			last = p
+		}
+	}
+
+	elems := strings.Split(rel, "/")
+	for i := 0; i < len(elems); i++ {
+		dir := strings.Join(elems[:i], "/")
+		for _, p := range m.patterns(dir) {
+			if p.Match(rel, isDir) {
+				last = p
+			}
+		}
+	}
+
+	return last
+}
+
+// patterns returns the patterns of the ignore file in dir.
+func (m *Matcher) patterns(dir string) []*Pattern {
+	if m.name == "" {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ps, ok := m.dirs[dir]; ok {
+		return ps
+	}
+
+	// unreadable ignore files are skipped like git does
+	ps, _ := ReadPatterns(filepath.Join(m.root, filepath.FromSlash(dir), m.name), dir)
+	m.dirs[dir] = ps
+
+	return ps
+}
+
+// sub returns the matcher for the nested repository at dir, or nil if dir is
+// not a nested repository.
+func (m *Matcher) sub(dir string) (*Matcher, error) {
+	if m.newSub == nil {
+		return nil, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sub, ok := m.subs[dir]; ok {
+		return sub, nil
+	}
+
+	var sub *Matcher
+	path := filepath.Join(m.root, filepath.FromSlash(dir))
+	if lib.IsExist(filepath.Join(path, ".git")) {
+		var err error
+		sub, err = m.newSub(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	m.subs[dir] = sub
+
+	return sub, nil
+}
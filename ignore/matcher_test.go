@@ -0,0 +1,243 @@
+package ignore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if strings.HasSuffix(name, "/") {
+			if err := os.MkdirAll(path, 0755); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func git(t *testing.T, dir string, stdin string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_NOSYSTEM=1", "HOME="+dir, "XDG_CONFIG_HOME="+dir)
+	cmd.Stdin = strings.NewReader(stdin)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		// git check-ignore exits with 1 when no paths are ignored
+		if ee, ok := err.(*exec.ExitError); !ok || ee.ExitCode() != 1 {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	return out.String()
+}
+
+// The matcher should be compatible with `git check-ignore`.
+func TestGitIgnore_CompatibleWithGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root, err := ioutil.TempDir("", "filelint-ignore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	os.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	defer os.Unsetenv("GIT_CONFIG_NOSYSTEM")
+
+	git(t, root, "", "init", "-q", ".")
+	git(t, root, "", "config", "core.excludesFile", filepath.Join(root, "global-ignore"))
+
+	writeFiles(t, root, map[string]string{
+		".gitignore": strings.Join([]string{
+			"# comment",
+			"*.log",
+			"!important.log",
+			"/build",
+			"docs/*.html",
+			"**/tmp/**",
+			"out/",
+			"\\#hash",
+			"trailing   ",
+			"[abc].txt",
+			"[!x]y.md",
+			"a/**/z",
+			"*.gen",
+			"ignored-dir/",
+			"!ignored-dir/keep",
+		}, "\n"),
+		"global-ignore":         "*.swp\nglobal-ignore\n",
+		".git/info/exclude":     "secret*\n",
+		"sub/.gitignore":        "!debug.log\n*.o\n/local\n",
+		"sub/deeper/.gitignore": "!*.o\n",
+		"nested/.gitignore":     "*.txt\n",
+
+		"a.log":             "",
+		"important.log":     "",
+		"sub/important.log": "",
+		"sub/debug.log":     "",
+		"debug.log":         "",
+		"build/a":           "",
+		"sub/build/a":       "",
+		"docs/a.html":       "",
+		"docs/b/a.html":     "",
+		"x/tmp/a":           "",
+		"tmp/b/c":           "",
+		"out/a":             "",
+		"sub/out/a":         "",
+		"out.txt":           "",
+		"#hash":             "",
+		"hash":              "",
+		"trailing":          "",
+		"a.txt":             "",
+		"d.txt":             "",
+		"ay.md":             "",
+		"xy.md":             "",
+		"a/z":               "",
+		"a/b/c/z":           "",
+		"b/a/z":             "",
+		"x.swp":             "",
+		"sub/y.swp":         "",
+		"secret.txt":        "",
+		"sub/secret/a":      "",
+		"sub/a.o":           "",
+		"sub/deeper/a.o":    "",
+		"sub/deeper/x/a.o":  "",
+		"sub/local":         "",
+		"sub/deeper/local":  "",
+		"ignored-dir/keep":  "",
+		"ignored-dir/other": "",
+		"nested/a.txt":      "",
+		"nested/a.log":      "",
+		"nested/b.md":       "",
+		"plain.md":          "",
+		"dir/":              "",
+		"dir/plain.go":      "",
+	})
+
+	// files in nested repositories follow the rules of the nested ones
+	git(t, filepath.Join(root, "nested"), "", "init", "-q", ".")
+
+	m, err := NewGitIgnore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	if err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		if rel == "." || rel == ".git" || rel == filepath.Join("nested", ".git") {
+			if info.IsDir() && rel != "." {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var parentPaths, nestedPaths []string
+	for _, p := range paths {
+		if strings.HasPrefix(p, "nested/") {
+			nestedPaths = append(nestedPaths, strings.TrimPrefix(p, "nested/"))
+		} else {
+			parentPaths = append(parentPaths, p)
+		}
+	}
+
+	want := make(map[string]bool)
+	for _, p := range strings.Split(git(t, root, strings.Join(parentPaths, "\n"), "check-ignore", "--stdin"), "\n") {
+		if p != "" {
+			want[p] = true
+		}
+	}
+	for _, p := range strings.Split(git(t, filepath.Join(root, "nested"), strings.Join(nestedPaths, "\n"), "check-ignore", "--stdin"), "\n") {
+		if p != "" {
+			want["nested/"+p] = true
+		}
+	}
+
+	assert.NotEmpty(t, want)
+
+	for _, p := range paths {
+		path := filepath.Join(root, filepath.FromSlash(p))
+		got := m.Ignore(path, isDir(path))
+		assert.Equal(t, want[p], got, p)
+	}
+}
+
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
+
+func TestPattern_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		base    string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"*.go", "", "a.go", false, true},
+		{"*.go", "", "a/b.go", false, true},
+		{"*.go", "a", "b.go", false, false},
+		{"*.go", "a", "a/b/c.go", false, true},
+		{"/a.go", "", "b/a.go", false, false},
+		{"a/*.go", "", "a/b/c.go", false, false},
+		{"dir/", "", "dir", false, false},
+		{"dir/", "", "dir", true, true},
+		{"**/foo", "", "a/b/foo", false, true},
+		{"**/foo", "", "foo", false, true},
+		{"a/**", "", "a", true, false},
+		{"a/**", "", "a/b/c", false, true},
+		{"a/**/b", "", "a/b", false, true},
+		{"a/**/b", "", "a/x/y/b", false, true},
+		{"a**b", "", "axyb", false, true},
+		{"a**b", "", "ax/yb", false, false},
+		{"?.md", "", "a.md", false, true},
+		{"?.md", "", "ab.md", false, false},
+		{"[a-c].md", "", "b.md", false, true},
+		{"[!a-c].md", "", "b.md", false, false},
+		{"[^a-c].md", "", "d.md", false, true},
+		{"[[:digit:]].md", "", "1.md", false, true},
+		{"\\!a", "", "!a", false, true},
+		{"a\\ ", "", "a ", false, true},
+		{"[a", "", "[a", false, true},
+	}
+
+	for _, tt := range tests {
+		p := ParsePattern(tt.pattern, tt.base)
+		if !assert.NotNil(t, p, tt.pattern) {
+			continue
+		}
+		assert.Equal(t, tt.want, p.Match(tt.path, tt.isDir), "%q %q", tt.pattern, tt.path)
+	}
+}
+
+func TestParsePattern_Empty(t *testing.T) {
+	for _, line := range []string{"", "   ", "# comment", "!", "/"} {
+		assert.Nil(t, ParsePattern(line, ""), line)
+	}
+}
@@ -0,0 +1,237 @@
+package ignore
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Pattern is a pattern of gitignore files.
+type Pattern struct {
+	// Source is the file and the line number where the pattern is written
+	Source string
+	Line   int
+
+	text     string
+	re       *regexp.Regexp
+	base     string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ParsePattern parses a line of gitignore files in base directory.
+// It returns nil if the line is blank or a comment.
+func ParsePattern(line, base string) *Pattern {
+	line = strings.TrimSuffix(line, "\r")
+	line = trimTrailingSpaces(line)
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	p := &Pattern{text: line, base: base}
+
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+
+	if strings.Contains(line, "/") {
+		p.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+
+	if line == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile("^" + translate(line) + "$")
+	if err != nil {
+		// git ignores broken patterns too
+		return nil
+	}
+	p.re = re
+
+	return p
+}
+
+// String returns the pattern as written in the file.
+func (p *Pattern) String() string {
+	return p.text
+}
+
+// Negate reports whether the pattern re-includes matched paths.
+func (p *Pattern) Negate() bool {
+	return p.negate
+}
+
+// Match reports whether the pattern matches the slash separated path relative
+// to the root of the repository.
+func (p *Pattern) Match(rel string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	if p.base != "" {
+		if !strings.HasPrefix(rel, p.base+"/") {
+			return false
+		}
+		rel = rel[len(p.base)+1:]
+	}
+
+	if !p.anchored {
+		rel = path.Base(rel)
+	}
+
+	return p.re.MatchString(rel)
+}
+
+func trimTrailingSpaces(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	return line
+}
+
+// translate converts the wildmatch pattern into the regular expression.
+func translate(pattern string) string {
+	var buf strings.Builder
+	rs := []rune(pattern)
+
+	for i := 0; i < len(rs); i++ {
+		c := rs[i]
+
+		switch c {
+		case '\\':
+			if i+1 < len(rs) {
+				i++
+				buf.WriteString(regexp.QuoteMeta(string(rs[i])))
+			}
+		case '*':
+			if i+1 < len(rs) && rs[i+1] == '*' {
+				atStart := i == 0 || rs[i-1] == '/'
+				j := i
+				for j < len(rs) && rs[j] == '*' {
+					j++
+				}
+				atEnd := j == len(rs) || rs[j] == '/'
+
+				if atStart && atEnd {
+					switch {
+					case j == len(rs):
+						// "a/**" matches everything inside "a"
+						buf.WriteString(".*")
+					default:
+						// "**/a" and "a/**/b" match zero or more directories
+						buf.WriteString("(?:.*/)?")
+						j++
+					}
+					i = j - 1
+					continue
+				}
+
+				// other consecutive asterisks are the same as a single asterisk
+				i = j - 1
+			}
+			buf.WriteString("[^/]*")
+		case '?':
+			buf.WriteString("[^/]")
+		case '[':
+			class, n := translateClass(rs[i:])
+			if n == 0 {
+				buf.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			buf.WriteString(class)
+			i += n - 1
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return buf.String()
+}
+
+// translateClass converts the bracket expression at the beginning of rs.
+// It returns the number of runes consumed, or 0 if the bracket is not closed.
+func translateClass(rs []rune) (string, int) {
+	var buf strings.Builder
+	buf.WriteString("[")
+
+	i := 1
+	if i < len(rs) && (rs[i] == '!' || rs[i] == '^') {
+		buf.WriteString("^/")
+		i++
+	}
+
+	for first := true; i < len(rs); i++ {
+		c := rs[i]
+
+		switch {
+		case c == ']' && !first:
+			buf.WriteString("]")
+			return buf.String(), i + 1
+		case c == '[' && i+1 < len(rs) && rs[i+1] == ':':
+			end := strings.Index(string(rs[i:]), ":]")
+			if end < 0 {
+				return "", 0
+			}
+			class := string(rs[i : i+end+2])
+			buf.WriteString(class)
+			i += len([]rune(class)) - 1
+		case c == '\\' && i+1 < len(rs):
+			i++
+			buf.WriteString(regexp.QuoteMeta(string(rs[i])))
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+
+		first = false
+	}
+
+	return "", 0
+}
+
+// ReadPatterns reads the patterns of a gitignore file for base directory.
+// It returns no patterns if the file doesn't exist.
+func ReadPatterns(file, base string) ([]*Pattern, error) {
+	fp, err := os.Open(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer fp.Close()
+
+	return parsePatterns(fp, file, base)
+}
+
+func parsePatterns(r io.Reader, source, base string) ([]*Pattern, error) {
+	var patterns []*Pattern
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if n == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		if p := ParsePattern(line, base); p != nil {
+			p.Source = source
+			p.Line = n
+			patterns = append(patterns, p)
+		}
+	}
+
+	return patterns, sc.Err()
+}
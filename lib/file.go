@@ -2,10 +2,14 @@ package lib
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 
 	"github.com/andrew-d/isbinary"
 )
@@ -32,24 +36,6 @@ func FindGitRootPath(path string) (string, error) {
 	return FindGitRootPath(parentPath)
 }
 
-func FindGitIgnore() (string, error) {
-	gitRoot, err := FindGitRootPath(".")
-	if err != nil {
-		if err == ErrNotGitRepository {
-			return "", nil
-		} else {
-			return "", err
-		}
-	}
-
-	path := filepath.Join(gitRoot, ".gitignore")
-	if IsExist(path) {
-		return path, nil
-	} else {
-		return "", nil
-	}
-}
-
 func IsExist(path string) bool {
 	_, err := os.Stat(path)
 	return !os.IsNotExist(err)
@@ -70,25 +56,14 @@ func IsBinary(path string) bool {
 	}
 	defer fp.Close()
 
-	buf, err := ioutil.ReadAll(fp)
-	if err != nil {
+	// isbinary inspects only the first BlockSize bytes
+	buf := make([]byte, isbinary.BlockSize)
+	n, err := io.ReadFull(fp, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 		return false
 	}
 
-	return isbinary.Test(buf)
-}
-
-func FindTextFiles(paths []string) []string {
-	new := make([]string, 0, len(paths))
-
-	for _, path := range paths {
-		if IsDir(path) || IsBinary(path) {
-			continue
-		}
-		new = append(new, path)
-	}
-
-	return new
+	return isbinary.Test(buf[:n])
 }
 
 func GetHomeDir() string {
@@ -106,3 +81,72 @@ func GetHomeDir() string {
 
 	return dir
 }
+
+// ParseSize parses a size such as "512", "100KB" or "10MB" into bytes.
+func ParseSize(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	unit := int64(1)
+
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(str, u.suffix) {
+			str = strings.TrimSpace(strings.TrimSuffix(str, u.suffix))
+			unit = u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(str, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	return n * unit, nil
+}
+
+var sizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// WriteFileAtomic writes src to the file at path with the permission perm.
+// The file is replaced atomically so that concurrent readers never read a
+// partially written file.
+func WriteFileAtomic(path string, src []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	// the temporary file is created with 0600
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if _, err := tmp.Write(src); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package lib
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		src     string
+		want    int64
+		wanterr bool
+	}{
+		{"0", 0, false},
+		{"512", 512, false},
+		{"512B", 512, false},
+		{"100KB", 100 << 10, false},
+		{"10mb", 10 << 20, false},
+		{" 1 GB ", 1 << 30, false},
+		{"", 0, true},
+		{"MB", 0, true},
+		{"-1", 0, true},
+		{"1TB", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSize(tt.src)
+		assert.Equal(t, tt.want, got, tt.src)
+		assert.Equal(t, tt.wanterr, err != nil, tt.src)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filelint-lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a", "b.json")
+	for _, src := range []string{"a", "b"} {
+		assert.NoError(t, WriteFileAtomic(path, []byte(src), 0644))
+
+		got, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, src, string(got))
+
+		fi, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0644), fi.Mode().Perm())
+	}
+
+	// no temporary files are left
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
@@ -0,0 +1,145 @@
+package lint
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// Context is the information about the file being linted.
+type Context struct {
+	Filename string
+
+	// FileType is the type detected by package filetype such as "markdown",
+	// or empty if it is unknown
+	FileType string
+
+	// Paths is all the lint target files, or nil if it is unknown
+	Paths *Paths
+}
+
+// ContextRule is implemented by rules which depend on the file being linted,
+// such as its type. Linter calls SetContext before linting the file.
+type ContextRule interface {
+	Rule
+	SetContext(ctx *Context)
+}
+
+// markdownRule is embedded in the rules which lint only Markdown documents.
+type markdownRule struct {
+	ctx *Context
+}
+
+func (r *markdownRule) SetContext(ctx *Context) {
+	r.ctx = ctx
+}
+
+// IsMarkdown reports whether the file is a Markdown document.
+func (ctx *Context) IsMarkdown() bool {
+	return ctx != nil && ctx.FileType == "markdown"
+}
+
+type RegionKind string
+
+const (
+	// TextRegion is prose, or the whole document which is not Markdown
+	TextRegion RegionKind = "text"
+
+	// CodeRegion is a fenced code block including the fences
+	CodeRegion RegionKind = "code"
+
+	// FrontMatterRegion is YAML or TOML front matter including the delimiters
+	FrontMatterRegion RegionKind = "front-matter"
+)
+
+// Region is a range of lines of a document.
+type Region struct {
+	Kind RegionKind
+
+	// Begin and End are the first and the last line numbers starting with 1
+	Begin int
+	End   int
+}
+
+// Regions returns the regions of the document s. Only Markdown documents are
+// divided into regions, and other documents are a single text region.
+func (ctx *Context) Regions(s []byte) []*Region {
+	lines := bytes.Split(s, []byte("\n"))
+	if !ctx.IsMarkdown() {
+		return []*Region{{Kind: TextRegion, Begin: 1, End: len(lines)}}
+	}
+
+	var regions []*Region
+	for i, kind := range markdownLineKinds(lines) {
+		if n := len(regions); n > 0 && regions[n-1].Kind == kind {
+			regions[n-1].End = i + 1
+			continue
+		}
+		regions = append(regions, &Region{Kind: kind, Begin: i + 1, End: i + 1})
+	}
+	return regions
+}
+
+// markdownLineKinds returns the region kind of each line of a Markdown
+// document.
+func markdownLineKinds(lines [][]byte) []RegionKind {
+	kinds := make([]RegionKind, len(lines))
+	var sc markdownScanner
+	for i, l := range lines {
+		kinds[i] = sc.scan(l)
+	}
+	return kinds
+}
+
+var (
+	fencePattern          = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	frontMatterPattern    = regexp.MustCompile(`^(---|\+\+\+)\s*$`)
+	frontMatterEndPattern = regexp.MustCompile(`^(---|\.\.\.|\+\+\+)\s*$`)
+)
+
+// markdownScanner reads a Markdown document line by line and tells the region
+// of each line, so that it is used in both normal and streaming mode.
+type markdownScanner struct {
+	num int
+
+	// fence is the opening code fence like "```" in a code block
+	fence []byte
+
+	inFrontMatter bool
+}
+
+func (sc *markdownScanner) scan(line []byte) RegionKind {
+	sc.num++
+	line = bytes.TrimSuffix(line, []byte("\r"))
+
+	switch {
+	case sc.num == 1 && frontMatterPattern.Match(line):
+		sc.inFrontMatter = true
+		return FrontMatterRegion
+
+	case sc.inFrontMatter:
+		if frontMatterEndPattern.Match(line) {
+			sc.inFrontMatter = false
+		}
+		return FrontMatterRegion
+
+	case sc.fence != nil:
+		// the closing fence is at least as long as the opening one and
+		// followed only by spaces
+		trimmed := bytes.TrimLeft(line, " ")
+		if len(line)-len(trimmed) < 4 && bytes.HasPrefix(trimmed, sc.fence) &&
+			len(bytes.TrimSpace(bytes.TrimLeft(trimmed, string(sc.fence[:1])))) == 0 {
+			sc.fence = nil
+		}
+		return CodeRegion
+	}
+
+	if m := fencePattern.FindSubmatch(line); m != nil {
+		// backtick fences can't have backticks in the info string
+		if m[1][0] != '`' || bytes.IndexByte(line[len(m[0]):], '`') < 0 {
+			sc.fence = append([]byte{}, m[1]...)
+			return CodeRegion
+		}
+	}
+
+	return TextRegion
+}
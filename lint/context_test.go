@@ -0,0 +1,72 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_Regions(t *testing.T) {
+	markdown := &Context{FileType: "markdown"}
+
+	tests := []struct {
+		ctx  *Context
+		src  string
+		want []*Region
+	}{
+		{
+			ctx: markdown,
+			src: "---\ntitle: a\n---\n# a\n\n```go\nb\n```\nc\n",
+			want: []*Region{
+				{Kind: FrontMatterRegion, Begin: 1, End: 3},
+				{Kind: TextRegion, Begin: 4, End: 5},
+				{Kind: CodeRegion, Begin: 6, End: 8},
+				{Kind: TextRegion, Begin: 9, End: 10},
+			},
+		},
+		{
+			// a fence is closed by the same or longer fence
+			ctx: markdown,
+			src: "~~~~\n~~~\n```\n~~~~~\na",
+			want: []*Region{
+				{Kind: CodeRegion, Begin: 1, End: 4},
+				{Kind: TextRegion, Begin: 5, End: 5},
+			},
+		},
+		{
+			// an unclosed fence lasts until the end, and "``` `" is not a fence
+			ctx: markdown,
+			src: "a ``` `\n  ```\nb",
+			want: []*Region{
+				{Kind: TextRegion, Begin: 1, End: 1},
+				{Kind: CodeRegion, Begin: 2, End: 3},
+			},
+		},
+		{
+			// "---" is front matter only at the beginning
+			ctx: markdown,
+			src: "a\n---\nb",
+			want: []*Region{
+				{Kind: TextRegion, Begin: 1, End: 3},
+			},
+		},
+		{
+			ctx: &Context{FileType: "go"},
+			src: "```\na\n```",
+			want: []*Region{
+				{Kind: TextRegion, Begin: 1, End: 3},
+			},
+		},
+		{
+			ctx: nil,
+			src: "a\n",
+			want: []*Region{
+				{Kind: TextRegion, Begin: 1, End: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.ctx.Regions([]byte(tt.src)), tt.src)
+	}
+}
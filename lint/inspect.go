@@ -0,0 +1,45 @@
+package lint
+
+import "bytes"
+
+// Style is the coding style of a file found by Inspect.
+type Style struct {
+	Linebreak     LinebreakStyle
+	FinalNewlines int
+	BOM           bool
+
+	// Indent is the style used by most indented lines,
+	// or empty if the file has no indented lines.
+	Indent IndentStyle
+}
+
+// Inspect finds the coding style of the source.
+func Inspect(s []byte) *Style {
+	style := &Style{
+		Linebreak:     detectLinebreakStyle(s),
+		FinalNewlines: countFinalNewlines(s),
+		BOM:           bytes.HasPrefix(s, UTF8BOMs),
+	}
+
+	var tabs, spaces int
+	for _, l := range bytes.Split(s, style.Linebreak) {
+		indent := leadingSpace(l)
+		if len(indent) == 0 || len(indent) == len(l) {
+			continue
+		}
+		if indent[0] == '\t' {
+			tabs++
+		} else {
+			spaces++
+		}
+	}
+	switch {
+	case tabs == 0 && spaces == 0:
+	case tabs >= spaces:
+		style.Indent = TabIndent
+	default:
+		style.Indent = SpaceIndent
+	}
+
+	return style
+}
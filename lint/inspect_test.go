@@ -0,0 +1,32 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	tests := []struct {
+		src  string
+		want *Style
+	}{
+		{
+			src:  "a\n",
+			want: &Style{Linebreak: UnixStyleLinebreak, FinalNewlines: 1},
+		},
+		{
+			src:  "\xef\xbb\xbfa\r\n\tb\r\n    c\r\n\td\r\n\r\n",
+			want: &Style{Linebreak: WindowsStyleLinebreak, FinalNewlines: 2, BOM: true, Indent: TabIndent},
+		},
+		{
+			// blank lines are not indentation
+			src:  "a\n  b\n\t\n",
+			want: &Style{Linebreak: UnixStyleLinebreak, FinalNewlines: 1, Indent: SpaceIndent},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Inspect([]byte(tt.src)), tt.src)
+	}
+}
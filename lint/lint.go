@@ -2,6 +2,7 @@ package lint
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 )
 
@@ -42,11 +43,13 @@ func (rmap *RuleMap) Size() int {
 	return len(rmap.m)
 }
 
+// GetAllRuleNames returns the sorted names of the rules.
 func (rmap *RuleMap) GetAllRuleNames() []string {
-	names := make([]string, len(rmap.m))
-	for name, _ := range rmap.m {
+	names := make([]string, 0, len(rmap.m))
+	for name := range rmap.m {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -59,12 +62,57 @@ type Rule interface {
 type MetaData struct {
 	Name        string
 	Description string
+	Options     []*Option
+
+	// Fixable is true if the rule can fix the problems
+	Fixable bool
+
+	Examples []*Example
 
 	// rank is the order called by linter.
 	// this is evaluated in descending order.
+	// The rules which report positions without changing lines have rank 1,
+	// so that they run before the fixes which insert or remove lines and
+	// their reports point at the lines of the file
 	rank int
 }
 
+// Rank returns the order called by linter. Rules with lower rank are called first.
+func (md *MetaData) Rank() int {
+	return md.rank
+}
+
+// Example is an example source which the rule reports.
+type Example struct {
+	// Options is the options of the rule except enforce
+	Options map[string]interface{}
+	Source  string
+
+	// FileType is the type of the example file for the rules depending on it
+	FileType string
+
+	// Filename is the path of the example file for the rules linting paths,
+	// and Paths is the other lint target files
+	Filename string
+	Paths    []string
+}
+
+// Run lints the example source with rule.
+func (ex *Example) Run(rule Rule) (*Result, error) {
+	r, err := rule.New(ex.Options)
+	if err != nil {
+		return nil, err
+	}
+	if cr, ok := r.(ContextRule); ok {
+		cr.SetContext(&Context{
+			Filename: ex.Filename,
+			FileType: ex.FileType,
+			Paths:    NewPaths(append([]string{ex.Filename}, ex.Paths...)),
+		})
+	}
+	return r.Lint([]byte(ex.Source))
+}
+
 type Result struct {
 	Fixed   []byte
 	Reports []*Report
@@ -91,9 +139,40 @@ func (res *Result) AddReport(col, row int, message string) {
 	res.Reports = append(res.Reports, NewReport(col, row, message))
 }
 
+// AddUnfixableReport adds the report which is not fixed by the fixable rule.
+func (res *Result) AddUnfixableReport(col, row int, message string) {
+	rep := NewReport(col, row, message)
+	rep.unfixable = true
+	res.Reports = append(res.Reports, rep)
+}
+
+// Severity is how serious the reports of a rule are.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
 type Report struct {
 	position *Position
 	message  string
+
+	// rule is the name of the rule reported this, severity is the severity
+	// of the rule, and fixable is whether the rule fixes this, which are set
+	// by Linter
+	rule     string
+	severity Severity
+	fixable  bool
+
+	// unfixable is set by the fixable rules for the reports which they can't
+	// fix, such as syntax errors
+	unfixable bool
+
+	// text is the reported line in the source linted by the rule, which is
+	// set by Linter unless the file is linted as a stream
+	text    string
+	hasText bool
 }
 
 func NewReport(col, row int, message string) *Report {
@@ -103,6 +182,41 @@ func NewReport(col, row int, message string) *Report {
 	}
 }
 
+// Line returns the line number of the report, or 0 if the report is about the whole file.
+func (rep *Report) Line() int {
+	return rep.position.column
+}
+
+// LineText returns the reported line without the line break in the source
+// which the rule linted, which differs from the file if the preceding rules
+// fixed it. ok is false if the source is not kept, such as in stream mode.
+func (rep *Report) LineText() (text string, ok bool) {
+	return rep.text, rep.hasText
+}
+
+func (rep *Report) Message() string {
+	return rep.message
+}
+
+// Rule returns the name of the rule which reported this.
+func (rep *Report) Rule() string {
+	return rep.rule
+}
+
+// Severity returns the severity of the report, which is an error unless the
+// rule is configured otherwise.
+func (rep *Report) Severity() Severity {
+	if rep.severity == "" {
+		return SeverityError
+	}
+	return rep.severity
+}
+
+// Fixable returns whether the report is fixed in the result of the rule.
+func (rep *Report) Fixable() bool {
+	return rep.fixable
+}
+
 func (rep *Report) String() string {
 	return fmt.Sprintf("%s: %s", rep.position.String(), rep.message)
 }
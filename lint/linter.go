@@ -1,7 +1,10 @@
 package lint
 
 import (
+	"bytes"
+	"io"
 	"io/ioutil"
+	"os"
 	"sort"
 )
 
@@ -9,57 +12,307 @@ type Linter struct {
 	filename string
 	source   []byte
 	rules    RankedRules
+	stream   bool
+	ctx      *Context
+
+	// rejected is the reports of StatRules if the file is rejected without
+	// being read
+	rejected *Result
+
+	// severities is the severities of the rules by their names, the rules
+	// not in it report errors
+	severities map[string]Severity
+
+	// linted is the names of the rules which linted the file
+	linted []string
 }
 
+// StatRule is implemented by rules which can lint a file by its stat before
+// reading it. The file reported by a StatRule is rejected, and the other
+// rules are not run.
+type StatRule interface {
+	Rule
+	LintStat(fi os.FileInfo) (*Result, error)
+}
+
+// RankedRules is the rules sorted in the order to run, by their ranks and
+// then their names, so that the fixes are applied deterministically.
 type RankedRules []Rule
 
+func newRankedRules(rules []Rule) RankedRules {
+	rs := make(RankedRules, len(rules))
+	copy(rs, rules)
+	sort.Stable(rs)
+	return rs
+}
+
 func (r RankedRules) Len() int {
 	return len(r)
 }
 
 func (r RankedRules) Less(i int, j int) bool {
-	return r[i].MetaData().rank < r[j].MetaData().rank
+	mi, mj := r[i].MetaData(), r[j].MetaData()
+	if mi.rank != mj.rank {
+		return mi.rank < mj.rank
+	}
+	return mi.Name < mj.Name
 }
 
 func (r RankedRules) Swap(i int, j int) {
 	r[i], r[j] = r[j], r[i]
 }
 
-func NewLinter(filename string, rules []Rule) (*Linter, error) {
+// NewLinter returns the linter of the file of the type, which is detected by
+// package filetype once for all the targets and the rules of the file.
+func NewLinter(filename, typ string, rules []Rule) (*Linter, error) {
+	// the file rejected by its size is not read
+	if rejected, err := NewStatLinter(filename, rules); err != nil || rejected != nil {
+		return rejected, err
+	}
+
 	src, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
 
-	rs := RankedRules(rules)
-	sort.Sort(rs)
+	rs := newRankedRules(rules)
 
 	linter := &Linter{
 		filename: filename,
 		source:   src,
 		rules:    rs,
+		ctx:      &Context{Filename: filename, FileType: typ},
 	}
 
 	return linter, nil
 }
 
+// NewStreamLinter returns the linter which reads the file line by line
+// instead of loading it into memory.
+// It runs only the rules implementing StreamRule, and it can't fix the file.
+func NewStreamLinter(filename, typ string, rules []Rule) *Linter {
+	rs := newRankedRules(rules)
+
+	return &Linter{
+		filename: filename,
+		rules:    rs,
+		stream:   true,
+		ctx:      &Context{Filename: filename, FileType: typ},
+	}
+}
+
+// NewStatLinter returns the linter which reports the file rejected by
+// StatRules without reading it, or nil if the file is not rejected.
+func NewStatLinter(filename string, rules []Rule) (*Linter, error) {
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := newRankedRules(rules)
+
+	linter := &Linter{
+		filename: filename,
+		rules:    rs,
+		ctx:      &Context{Filename: filename},
+	}
+	res, err := linter.lintStat(fi)
+	if err != nil || len(res.Reports) == 0 {
+		return nil, err
+	}
+	linter.rejected = res
+
+	return linter, nil
+}
+
+// SetPaths sets the lint target files for the rules comparing the path of
+// the file with the others.
+func (linter *Linter) SetPaths(paths *Paths) {
+	linter.ctx.Paths = paths
+}
+
+// SetSeverities sets the severities of the reports of the rules by their
+// names.
+func (linter *Linter) SetSeverities(severities map[string]Severity) {
+	linter.severities = severities
+}
+
+// LintedRules returns the names of the rules which linted the file by Lint.
+// The rules which can't lint the file, such as the rules not implementing
+// StreamRule in stream mode, are not included.
+func (linter *Linter) LintedRules() []string {
+	return linter.linted
+}
+
+// CanFix reports whether the result of Lint has the fixed source.
+func (linter *Linter) CanFix() bool {
+	return !linter.stream && linter.rejected == nil
+}
+
 func (linter *Linter) Lint() (*Result, error) {
+	result, err := linter.lint()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range result.Reports {
+		if severity, ok := linter.severities[r.rule]; ok {
+			r.severity = severity
+		}
+	}
+	return result, nil
+}
+
+func (linter *Linter) lint() (*Result, error) {
+	if linter.rejected != nil {
+		return linter.rejected, nil
+	}
+	linter.linted = nil
+	if linter.stream {
+		return linter.lintStream()
+	}
+
 	result := NewResult()
 	src := make([]byte, len(linter.source))
 	copy(src, linter.source)
 
-	if len(linter.source) != 0 {
-		for _, rule := range linter.rules {
-			r, err := rule.Lint(src)
-			if err != nil {
-				return nil, err
-			}
-			result.Reports = append(result.Reports, r.Reports...)
-			src = r.Fixed
+	for _, rule := range linter.rules {
+		// only the paths are linted if the file is empty
+		if _, ok := rule.(PathRule); !ok && len(linter.source) == 0 {
+			continue
+		}
+
+		linter.setContext(rule)
+		r, err := rule.Lint(src)
+		if err != nil {
+			return nil, err
 		}
+		linter.setRule(r.Reports, rule)
+		if err := setLineTexts(r.Reports, src); err != nil {
+			return nil, err
+		}
+		result.Reports = append(result.Reports, r.Reports...)
+		src = r.Fixed
 	}
 
 	result.Set(src)
 
 	return result, nil
 }
+
+func (linter *Linter) lintStream() (*Result, error) {
+	result := NewResult()
+
+	fi, err := os.Stat(linter.filename)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() == 0 {
+		return result, nil
+	}
+
+	result, err = linter.lintStat(fi)
+	if err != nil || len(result.Reports) > 0 {
+		return result, err
+	}
+
+	for _, rule := range linter.rules {
+		sr, ok := rule.(StreamRule)
+		if !ok {
+			continue
+		}
+
+		linter.setContext(rule)
+		r, err := linter.lintStreamWith(sr)
+		if err != nil {
+			return nil, err
+		}
+		linter.setRule(r.Reports, rule)
+		result.Reports = append(result.Reports, r.Reports...)
+	}
+
+	return result, nil
+}
+
+// lintStat lints the file with StatRules.
+func (linter *Linter) lintStat(fi os.FileInfo) (*Result, error) {
+	result := NewResult()
+
+	for _, rule := range linter.rules {
+		sr, ok := rule.(StatRule)
+		if !ok {
+			continue
+		}
+
+		linter.setContext(rule)
+		r, err := sr.LintStat(fi)
+		if err != nil {
+			return nil, err
+		}
+		linter.setRule(r.Reports, rule)
+		result.Reports = append(result.Reports, r.Reports...)
+	}
+
+	return result, nil
+}
+
+func (linter *Linter) lintStreamWith(rule StreamRule) (*Result, error) {
+	fp, err := os.Open(linter.filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	return rule.LintStream(NewLineReader(fp))
+}
+
+func (linter *Linter) setContext(rule Rule) {
+	if cr, ok := rule.(ContextRule); ok {
+		cr.SetContext(linter.ctx)
+	}
+}
+
+func (linter *Linter) setRule(reports []*Report, rule Rule) {
+	md := rule.MetaData()
+	linter.linted = append(linter.linted, md.Name)
+	for _, r := range reports {
+		r.rule = md.Name
+		r.fixable = md.Fixable && !r.unfixable
+	}
+}
+
+// setLineTexts sets the reported lines in the source src to the reports.
+// The lines are read in the same way as LineReader reads files, so that they
+// are the same as the lines read from the file.
+func setLineTexts(reports []*Report, src []byte) error {
+	lines := make(map[int][]*Report)
+	max := 0
+	for _, r := range reports {
+		if n := r.Line(); n > 0 {
+			lines[n] = append(lines[n], r)
+			if n > max {
+				max = n
+			}
+		}
+	}
+	if max == 0 {
+		return nil
+	}
+
+	lr := NewLineReader(bytes.NewReader(src))
+	for {
+		l, err := lr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		for _, r := range lines[l.Num] {
+			r.text, r.hasText = string(l.Text), true
+		}
+		if l.Num >= max {
+			break
+		}
+	}
+	return nil
+}
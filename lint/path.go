@@ -0,0 +1,99 @@
+package lint
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Paths is the list of the lint target files for the rules comparing the
+// path of the file with the others. The list is loaded lazily on the first
+// use since walking the files again is costly. It is safe for concurrent use.
+type Paths struct {
+	load func() ([]string, error)
+
+	once sync.Once
+	err  error
+
+	// folded is the paths and their parent directories keyed by their lower
+	// cases
+	folded map[string][]string
+}
+
+// NewPaths returns the paths of the files.
+func NewPaths(files []string) *Paths {
+	return LoadPaths(func() ([]string, error) {
+		return files, nil
+	})
+}
+
+// LoadPaths returns the paths which will be loaded by load on the first use.
+func LoadPaths(load func() ([]string, error)) *Paths {
+	return &Paths{load: load}
+}
+
+func (ps *Paths) init() error {
+	ps.once.Do(func() {
+		files, err := ps.load()
+		if err != nil {
+			ps.err = err
+			return
+		}
+
+		ps.folded = make(map[string][]string)
+		for _, f := range files {
+			for _, p := range pathPrefixes(cleanPath(f)) {
+				k := strings.ToLower(p)
+				if !contains(ps.folded[k], p) {
+					ps.folded[k] = append(ps.folded[k], p)
+				}
+			}
+		}
+		for _, ss := range ps.folded {
+			sort.Strings(ss)
+		}
+	})
+	return ps.err
+}
+
+// CaseCollision returns the shortest part of the file path, which is the
+// path itself or one of its parent directories, and the other paths which
+// are the same as the part ignoring cases. It returns an empty part if the
+// file path collides with nothing.
+func (ps *Paths) CaseCollision(file string) (string, []string, error) {
+	if err := ps.init(); err != nil {
+		return "", nil, err
+	}
+
+	for _, p := range pathPrefixes(cleanPath(file)) {
+		var others []string
+		for _, q := range ps.folded[strings.ToLower(p)] {
+			if q != p {
+				others = append(others, q)
+			}
+		}
+		if len(others) > 0 {
+			return p, others, nil
+		}
+	}
+	return "", nil, nil
+}
+
+// cleanPath returns the path separated by slashes without "./".
+func cleanPath(file string) string {
+	return path.Clean(filepath.ToSlash(file))
+}
+
+// pathPrefixes returns the parent directories of the path and the path
+// itself, such as "a", "a/b" and "a/b/c" for "a/b/c".
+func pathPrefixes(p string) []string {
+	var prefixes []string
+	for i := 0; i < len(p); i++ {
+		if p[i] == '/' && i > 0 {
+			prefixes = append(prefixes, p[:i])
+		}
+	}
+	return append(prefixes, p)
+}
@@ -0,0 +1,60 @@
+package lint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaths_CaseCollision(t *testing.T) {
+	paths := NewPaths([]string{
+		"README.md",
+		"readme.md",
+		"Readme.MD",
+		"docs/a.md",
+		"Docs/b.md",
+		"src/main.go",
+		"./src/Main_test.go",
+	})
+
+	tests := []struct {
+		file   string
+		part   string
+		others []string
+	}{
+		{file: "README.md", part: "README.md", others: []string{"Readme.MD", "readme.md"}},
+		{file: "./readme.md", part: "readme.md", others: []string{"README.md", "Readme.MD"}},
+		{file: "docs/a.md", part: "docs", others: []string{"Docs"}},
+		{file: "Docs/b.md", part: "Docs", others: []string{"docs"}},
+		{file: "src/main.go", part: "", others: nil},
+		{file: "src/Main_test.go", part: "", others: nil},
+	}
+
+	for _, tt := range tests {
+		part, others, err := paths.CaseCollision(tt.file)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.part, part, tt.file)
+		assert.Equal(t, tt.others, others, tt.file)
+	}
+}
+
+func TestPaths_LoadError(t *testing.T) {
+	calls := 0
+	paths := LoadPaths(func() ([]string, error) {
+		calls++
+		return nil, errors.New("walk error")
+	})
+
+	_, _, err := paths.CaseCollision("a")
+	assert.EqualError(t, err, "walk error")
+	_, _, err = paths.CaseCollision("b")
+	assert.EqualError(t, err, "walk error")
+	assert.Equal(t, 1, calls)
+}
+
+func TestPathPrefixes(t *testing.T) {
+	assert.Equal(t, []string{"a", "a/b", "a/b/c"}, pathPrefixes("a/b/c"))
+	assert.Equal(t, []string{"a"}, pathPrefixes("a"))
+	assert.Equal(t, []string{"/a", "/a/b"}, pathPrefixes("/a/b"))
+}
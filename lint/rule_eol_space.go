@@ -1,18 +1,146 @@
 package lint
 
-import "bytes"
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 var metadataNoEOLSpaceRule = &MetaData{
-	Name: "no-eol-space",
+	Name:        "no-eol-space",
+	Description: "This rule enforces no trailing whitespaces and tabs at the end of lines, and reports the column where the trailing whitespaces begin. Fenced code blocks in Markdown documents are not checked.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Source: "a \nb\t\n"},
+		{
+			Options:  map[string]interface{}{"allow-markdown-hard-break": true},
+			Source:   "line  \nbreak\nend of paragraph  \n",
+			FileType: "markdown",
+		},
+		{
+			Options:  map[string]interface{}{"skip-blank-lines": true, "ignore-comments": true},
+			Source:   "# comment \n\t\nkey: value \n",
+			FileType: "yaml",
+		},
+		{
+			Options: map[string]interface{}{"whitespace": "unicode"},
+			Source:  "a\u3000\nb\u00a0\n",
+		},
+		{
+			Options:  map[string]interface{}{"ignore-pattern": `(?ms)^cat <<EOF$.*?^EOF$`},
+			Source:   "cat <<EOF\nkept \nEOF\necho \n",
+			FileType: "shell",
+		},
+	},
+	Options: []*Option{
+		{
+			Name:        "allow-markdown-hard-break",
+			Type:        BoolOption,
+			Description: "allow two or more trailing spaces in Markdown documents which break lines in paragraphs",
+			Default:     false,
+		},
+		{
+			Name:        "skip-blank-lines",
+			Type:        BoolOption,
+			Description: "allow the lines which have only whitespaces",
+			Default:     false,
+		},
+		{
+			Name:        "ignore-comments",
+			Type:        BoolOption,
+			Description: "allow trailing whitespaces in the comment lines of the languages known by header rule",
+			Default:     false,
+		},
+		{
+			Name:        "whitespace",
+			Type:        StringOption,
+			Description: "the whitespaces to be removed, `blank` is spaces and tabs, `ascii` adds vertical tabs and form feeds, and `unicode` adds the other Unicode spaces such as NBSP and U+3000",
+			Default:     "blank",
+			Enum:        []string{"blank", "ascii", "unicode"},
+		},
+		{
+			Name:        "ignore-pattern",
+			Type:        StringOption,
+			Description: "the regular expression of the text to be ignored, the lines overlapping the matches are not checked. Large files linted line by line match it against each line",
+			Default:     "",
+		},
+	},
 
 	// this rule should be called before first-newline and final-newline
-	rank: 4,
+	rank: 6,
 }
 
-type NoEOLSpaceRule struct{}
+type NoEOLSpaceRule struct {
+	AllowMarkdownHardBreak bool
+	SkipBlankLines         bool
+	IgnoreComments         bool
+
+	// Whitespace is "blank", "ascii" or "unicode", empty means "blank"
+	Whitespace string
+
+	// IgnorePattern is the regular expression of the text which is not
+	// checked, or nil
+	IgnorePattern *regexp.Regexp
+
+	ctx *Context
+}
 
 func NewNoEOLSpaceRule(ops map[string]interface{}) (Rule, error) {
-	return &NoEOLSpaceRule{}, nil
+	rule := &NoEOLSpaceRule{}
+	ops = withDefaults(metadataNoEOLSpaceRule, ops)
+
+	if v, ok := ops["allow-markdown-hard-break"]; ok {
+		if value, ok := v.(bool); ok {
+			rule.AllowMarkdownHardBreak = value
+		} else {
+			return nil, fmt.Errorf("no-eol-space.allow-markdown-hard-break is only allow booleans: %v", v)
+		}
+	}
+
+	for name, p := range map[string]*bool{
+		"skip-blank-lines": &rule.SkipBlankLines,
+		"ignore-comments":  &rule.IgnoreComments,
+	} {
+		v, ok := ops[name]
+		if !ok {
+			continue
+		}
+		if value, ok := v.(bool); ok {
+			*p = value
+		} else {
+			return nil, fmt.Errorf("no-eol-space.%s is only allow booleans: %v", name, v)
+		}
+	}
+
+	if v, ok := ops["whitespace"]; ok {
+		s, _ := v.(string)
+		switch s = strings.ToLower(s); s {
+		case "blank", "ascii", "unicode":
+			rule.Whitespace = s
+		default:
+			return nil, fmt.Errorf("no-eol-space.whitespace is only allow blank, ascii or unicode: %v", v)
+		}
+	}
+
+	if v, ok := ops["ignore-pattern"]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("no-eol-space.ignore-pattern is only allow strings: %v", v)
+		}
+		if s != "" {
+			re, err := regexp.Compile(s)
+			if err != nil {
+				return nil, fmt.Errorf("no-eol-space.ignore-pattern is invalid: %v", err)
+			}
+			rule.IgnorePattern = re
+		}
+	}
+
+	return rule, nil
 }
 
 func (r *NoEOLSpaceRule) New(ops map[string]interface{}) (Rule, error) {
@@ -23,6 +151,10 @@ func (r *NoEOLSpaceRule) MetaData() *MetaData {
 	return metadataNoEOLSpaceRule
 }
 
+func (r *NoEOLSpaceRule) SetContext(ctx *Context) {
+	r.ctx = ctx
+}
+
 func (r *NoEOLSpaceRule) Lint(s []byte) (*Result, error) {
 	res := NewResult()
 	errmsg := "Trailing spaces/tabs at the end of lines are disallowed"
@@ -30,17 +162,194 @@ func (r *NoEOLSpaceRule) Lint(s []byte) (*Result, error) {
 	linebreak := detectLinebreakStyle(s)
 
 	ls := bytes.Split(s, linebreak)
+	kinds := r.lineKinds(ls)
+	ignored := r.ignoredLines(s, ls, linebreak)
+	comments := r.commentScanner()
 	for i, l := range ls {
-		ls[i] = bytes.TrimRight(l, " \t")
-		if bytes.HasSuffix(l, []byte(" ")) || bytes.HasSuffix(l, []byte("\t")) {
-			res.AddReport(i+1, 0, errmsg)
+		comment := comments.scan(l)
+		text := r.trim(l)
+		if len(text) == len(l) || ignored[i] {
+			continue
+		}
+		var next []byte
+		nextKind := TextRegion
+		if i+1 < len(ls) {
+			next, nextKind = ls[i+1], kinds[i+1]
 		}
+		if r.isAllowed(l, kinds[i], next, nextKind) || r.isSkipped(text, comment) {
+			continue
+		}
+		ls[i] = text
+		res.AddReport(i+1, utf8.RuneCount(text)+1, errmsg)
 	}
 	res.Set(bytes.Join(ls, linebreak))
 
 	return res, nil
 }
 
+func (r *NoEOLSpaceRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+	errmsg := "Trailing spaces/tabs at the end of lines are disallowed"
+
+	var (
+		sc          markdownScanner
+		comments    = r.commentScanner()
+		prev        *Line
+		prevKind    RegionKind
+		prevComment bool
+	)
+	// a line is checked when the next line is read, since hard breaks depend
+	// on the next line
+	check := func(next []byte, nextKind RegionKind) {
+		text := r.trim(prev.Text)
+		if len(text) == len(prev.Text) || r.isAllowed(prev.Text, prevKind, next, nextKind) || r.isSkipped(text, prevComment) {
+			return
+		}
+		if r.IgnorePattern != nil && r.IgnorePattern.Match(prev.Text) {
+			return
+		}
+		res.AddReport(prev.Num, utf8.RuneCount(text)+1, errmsg)
+	}
+
+	if err := forEachLine(lr, func(l *Line) {
+		kind := TextRegion
+		if r.ctx.IsMarkdown() {
+			kind = sc.scan(l.Text)
+		}
+		if prev != nil {
+			check(l.Text, kind)
+		}
+		prev, prevKind, prevComment = l, kind, comments.scan(l.Text)
+	}); err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		check(nil, TextRegion)
+	}
+
+	return res, nil
+}
+
+// lineKinds returns the region kinds of the lines. All lines are text unless
+// the file is Markdown.
+func (r *NoEOLSpaceRule) lineKinds(ls [][]byte) []RegionKind {
+	if r.ctx.IsMarkdown() {
+		return markdownLineKinds(ls)
+	}
+	kinds := make([]RegionKind, len(ls))
+	for i := range kinds {
+		kinds[i] = TextRegion
+	}
+	return kinds
+}
+
+// isAllowed reports whether the trailing spaces of the line are allowed.
+// In Markdown documents, code blocks are not touched, and two or more spaces
+// followed by a line in the same paragraph are a hard line break.
+func (r *NoEOLSpaceRule) isAllowed(line []byte, kind RegionKind, next []byte, nextKind RegionKind) bool {
+	if !r.ctx.IsMarkdown() {
+		return false
+	}
+	if kind == CodeRegion {
+		return true
+	}
+	if !r.AllowMarkdownHardBreak || kind != TextRegion || nextKind != TextRegion {
+		return false
+	}
+
+	text := bytes.TrimRight(line, " ")
+	return len(line)-len(text) >= 2 &&
+		len(bytes.TrimSpace(text)) > 0 && !bytes.HasSuffix(text, []byte("\t")) &&
+		len(bytes.TrimSpace(next)) > 0
+}
+
+// trim returns the line without the trailing whitespaces.
+func (r *NoEOLSpaceRule) trim(l []byte) []byte {
+	switch r.Whitespace {
+	case "ascii":
+		return bytes.TrimRight(l, " \t\v\f")
+	case "unicode":
+		return bytes.TrimRightFunc(l, func(c rune) bool {
+			return unicode.IsSpace(c) && c != '\r' && c != '\n'
+		})
+	}
+	return bytes.TrimRight(l, " \t")
+}
+
+// isSkipped reports whether the line is skipped by skip-blank-lines or
+// ignore-comments. text is the line without the trailing whitespaces.
+func (r *NoEOLSpaceRule) isSkipped(text []byte, comment bool) bool {
+	return (r.SkipBlankLines && len(text) == 0) || (r.IgnoreComments && comment)
+}
+
+// ignoredLines returns the lines overlapping the matches of ignore-pattern.
+func (r *NoEOLSpaceRule) ignoredLines(s []byte, ls [][]byte, linebreak []byte) map[int]bool {
+	ignored := map[int]bool{}
+	if r.IgnorePattern == nil {
+		return ignored
+	}
+
+	// the offsets of the beginnings of the lines
+	offsets := make([]int, len(ls))
+	for i := 1; i < len(ls); i++ {
+		offsets[i] = offsets[i-1] + len(ls[i-1]) + len(linebreak)
+	}
+	lineAt := func(offset int) int {
+		// the last line beginning at or before the offset
+		return sort.SearchInts(offsets, offset+1) - 1
+	}
+
+	for _, m := range r.IgnorePattern.FindAllIndex(s, -1) {
+		end := m[1]
+		if end > m[0] {
+			end--
+		}
+		for i := lineAt(m[0]); i <= lineAt(end); i++ {
+			ignored[i] = true
+		}
+	}
+	return ignored
+}
+
+// commentScanner returns the scanner of the comment lines, which never finds
+// comments unless ignore-comments is enabled.
+func (r *NoEOLSpaceRule) commentScanner() *commentScanner {
+	if !r.IgnoreComments || r.ctx == nil {
+		return &commentScanner{}
+	}
+	return &commentScanner{style: commentStyles[fileTypeCommentStyles[r.ctx.FileType]]}
+}
+
+// commentScanner finds the lines which are entirely comments. The lines of
+// block comments are comments from the line beginning with the begin marker
+// to the line containing the end marker.
+type commentScanner struct {
+	style   *commentStyle
+	inBlock bool
+}
+
+// scan reports whether the line is a comment line.
+func (sc *commentScanner) scan(l []byte) bool {
+	if sc.style == nil {
+		return false
+	}
+	l = bytes.TrimLeft(l, " \t")
+
+	if sc.style.begin != "" {
+		if !sc.inBlock {
+			if !bytes.HasPrefix(l, []byte(sc.style.begin)) {
+				return false
+			}
+			l = l[len(sc.style.begin):]
+		}
+		sc.inBlock = !bytes.Contains(l, []byte(strings.TrimSpace(sc.style.end)))
+		return true
+	}
+
+	marker := []byte(strings.TrimSpace(sc.style.line))
+	return len(marker) > 0 && bytes.HasPrefix(l, marker)
+}
+
 func init() {
 	definedRules.Set(&NoEOLSpaceRule{})
 }
@@ -1,6 +1,8 @@
 package lint
 
 import (
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -59,3 +61,169 @@ func TestNoEOLSpaceRule_Lint(t *testing.T) {
 		assert.Equal(t, tt.want, got.Fixed)
 	}
 }
+
+func TestNoEOLSpaceRule_Lint_Markdown(t *testing.T) {
+	markdown := &Context{FileType: "markdown"}
+
+	tests := []struct {
+		rule    NoEOLSpaceRule
+		src     string
+		want    string
+		reports int
+	}{
+		{
+			// hard breaks are not allowed by default
+			rule:    NoEOLSpaceRule{ctx: markdown},
+			src:     "a  \nb\n",
+			want:    "a\nb\n",
+			reports: 1,
+		},
+		{
+			rule:    NoEOLSpaceRule{AllowMarkdownHardBreak: true, ctx: markdown},
+			src:     "a  \r\nb   \r\nc \r\n",
+			want:    "a  \r\nb   \r\nc\r\n",
+			reports: 1,
+		},
+		{
+			// hard breaks at the end of paragraphs and tabs are not allowed
+			rule:    NoEOLSpaceRule{AllowMarkdownHardBreak: true, ctx: markdown},
+			src:     "a  \n\nb\t\nc\n  \nd  ",
+			want:    "a\n\nb\nc\n\nd",
+			reports: 4,
+		},
+		{
+			// code blocks are not touched
+			rule:    NoEOLSpaceRule{ctx: markdown},
+			src:     "a \n```go\nb \n```\nc \n~~~\nd \n",
+			want:    "a\n```go\nb \n```\nc\n~~~\nd \n",
+			reports: 2,
+		},
+		{
+			// front matter is not a paragraph
+			rule:    NoEOLSpaceRule{AllowMarkdownHardBreak: true, ctx: markdown},
+			src:     "---\ntitle: a  \nb: c\n---\n",
+			want:    "---\ntitle: a\nb: c\n---\n",
+			reports: 1,
+		},
+		{
+			// other files are not Markdown
+			rule:    NoEOLSpaceRule{AllowMarkdownHardBreak: true, ctx: &Context{FileType: "text"}},
+			src:     "a  \n```\nb \n```\n",
+			want:    "a\n```\nb\n```\n",
+			reports: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.want, string(got.Fixed), tt.src)
+		assert.Len(t, got.Reports, tt.reports, tt.src)
+	}
+}
+
+func TestNoEOLSpaceRule_Lint_Options(t *testing.T) {
+	tests := []struct {
+		rule    NoEOLSpaceRule
+		src     string
+		want    string
+		reports []string
+	}{
+		{
+			// the column where the trailing spaces begin is reported
+			rule:    NoEOLSpaceRule{},
+			src:     "ab \n\u3042\t\n",
+			want:    "ab\n\u3042\n",
+			reports: []string{"1:3", "2:2"},
+		},
+		{
+			rule:    NoEOLSpaceRule{SkipBlankLines: true},
+			src:     "a \n  \n\t\n",
+			want:    "a\n  \n\t\n",
+			reports: []string{"1:2"},
+		},
+		{
+			rule:    NoEOLSpaceRule{},
+			src:     "a\v\nb \nc\u3000\n",
+			want:    "a\v\nb \nc\u3000\n",
+			reports: []string{},
+		},
+		{
+			rule:    NoEOLSpaceRule{Whitespace: "ascii"},
+			src:     "a\v\nb \nc\f \n",
+			want:    "a\nb \nc\n",
+			reports: []string{"1:2", "3:2"},
+		},
+		{
+			rule:    NoEOLSpaceRule{Whitespace: "unicode"},
+			src:     "a\v\r\nb \r\nc \u3000\r\n",
+			want:    "a\r\nb\r\nc\r\n",
+			reports: []string{"1:2", "2:2", "3:2"},
+		},
+		{
+			rule:    NoEOLSpaceRule{IgnoreComments: true, ctx: &Context{FileType: "go"}},
+			src:     "// a \n\t// b \nc // d \n",
+			want:    "// a \n\t// b \nc // d\n",
+			reports: []string{"3:7"},
+		},
+		{
+			rule:    NoEOLSpaceRule{IgnoreComments: true, ctx: &Context{FileType: "css"}},
+			src:     "/* a \n * b \n */ \nc \n/* d */ \ne \n",
+			want:    "/* a \n * b \n */ \nc\n/* d */ \ne\n",
+			reports: []string{"4:2", "6:2"},
+		},
+		{
+			// the files of unknown types have no comments
+			rule:    NoEOLSpaceRule{IgnoreComments: true},
+			src:     "# a \n",
+			want:    "# a\n",
+			reports: []string{"1:4"},
+		},
+		{
+			rule:    NoEOLSpaceRule{IgnorePattern: regexp.MustCompile(`(?ms)^cat <<EOF$.*?^EOF$`)},
+			src:     "a \ncat <<EOF\nb \n \nEOF\nc \n",
+			want:    "a\ncat <<EOF\nb \n \nEOF\nc\n",
+			reports: []string{"1:2", "6:2"},
+		},
+		{
+			// the matches on the first and the last lines, and after empty lines
+			rule:    NoEOLSpaceRule{IgnorePattern: regexp.MustCompile(`(?m)^# .*$`)},
+			src:     "# a \nb \n\n\n# c \nd \n# e ",
+			want:    "# a \nb\n\n\n# c \nd\n# e ",
+			reports: []string{"2:2", "6:2"},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.want, string(got.Fixed), "%q", tt.src)
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String()[:strings.Index(r.String(), ": ")])
+		}
+		assert.Equal(t, tt.reports, reports, "%q", tt.src)
+	}
+}
+
+func TestNewNoEOLSpaceRule(t *testing.T) {
+	r, err := NewNoEOLSpaceRule(map[string]interface{}{"whitespace": "Unicode", "ignore-pattern": "^#"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "unicode", r.(*NoEOLSpaceRule).Whitespace)
+		assert.Equal(t, "^#", r.(*NoEOLSpaceRule).IgnorePattern.String())
+	}
+
+	_, err = NewNoEOLSpaceRule(map[string]interface{}{"whitespace": "all"})
+	assert.EqualError(t, err, "no-eol-space.whitespace is only allow blank, ascii or unicode: all")
+
+	_, err = NewNoEOLSpaceRule(map[string]interface{}{"skip-blank-lines": "yes"})
+	assert.EqualError(t, err, "no-eol-space.skip-blank-lines is only allow booleans: yes")
+
+	_, err = NewNoEOLSpaceRule(map[string]interface{}{"ignore-pattern": "("})
+	assert.EqualError(t, err, "no-eol-space.ignore-pattern is invalid: error parsing regexp: missing closing ): `(`")
+}
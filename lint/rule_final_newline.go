@@ -6,8 +6,23 @@ import (
 )
 
 var metadataFinalNewline = &MetaData{
-	Name: "final-newline",
-	rank: 5,
+	Name:        "final-newline",
+	Description: "This rule enforces some newlines at final of files.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Options: map[string]interface{}{"num": 1}, Source: "a"},
+		{Options: map[string]interface{}{"num": 1}, Source: "a\n\n\n"},
+	},
+	Options: []*Option{
+		{
+			Name:        "num",
+			Type:        IntOption,
+			Description: "the number of newlines at the end of files",
+			Default:     1,
+			Min:         intRange(0),
+		},
+	},
+	rank: 7,
 }
 
 type FinalNewlineRule struct {
@@ -16,6 +31,7 @@ type FinalNewlineRule struct {
 
 func NewFinalNewlineRule(ops map[string]interface{}) (Rule, error) {
 	rule := &FinalNewlineRule{}
+	ops = withDefaults(metadataFinalNewline, ops)
 
 	if v, ok := ops["num"]; ok {
 		if value, ok := v.(int); ok {
@@ -69,6 +85,29 @@ func (r *FinalNewlineRule) Lint(s []byte) (*Result, error) {
 	return res, nil
 }
 
+func (r *FinalNewlineRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+
+	n := 0
+	if err := forEachLine(lr, func(l *Line) {
+		if !l.IsBlank() {
+			n = 0
+		}
+		if l.Linebreak != nil {
+			n++
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	if n != r.Num {
+		errmsg := fmt.Sprintf("Files should end with %d newline(s) but %d newline(s)", r.Num, n)
+		res.AddReport(0, 0, errmsg)
+	}
+
+	return res, nil
+}
+
 func countFinalNewlines(s []byte) int {
 	n := 0
 
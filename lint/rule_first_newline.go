@@ -3,13 +3,29 @@ package lint
 import (
 	"bytes"
 	"fmt"
+	"io"
 )
 
 var metadataFirstNewlineRule = &MetaData{
-	Name: "first-newline",
+	Name:        "first-newline",
+	Description: "This rule enforces some newlines at first of files.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Options: map[string]interface{}{"num": 0}, Source: "\n\na\n"},
+		{Options: map[string]interface{}{"num": 1}, Source: "a\n"},
+	},
+	Options: []*Option{
+		{
+			Name:        "num",
+			Type:        IntOption,
+			Description: "the number of newlines at the beginning of files",
+			Default:     0,
+			Min:         intRange(0),
+		},
+	},
 
 	// this rule should called before final-newline rule
-	rank: 2,
+	rank: 3,
 }
 
 type FirstNewlineRule struct {
@@ -18,6 +34,7 @@ type FirstNewlineRule struct {
 
 func NewFirstNewlineRule(ops map[string]interface{}) (Rule, error) {
 	rule := &FirstNewlineRule{}
+	ops = withDefaults(metadataFirstNewlineRule, ops)
 
 	if v, ok := ops["num"]; ok {
 		if value, ok := v.(int); ok {
@@ -71,6 +88,32 @@ func (r *FirstNewlineRule) Lint(s []byte) (*Result, error) {
 	return res, nil
 }
 
+func (r *FirstNewlineRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+
+	n := 0
+	for {
+		l, err := lr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !l.IsBlank() || l.Linebreak == nil {
+			break
+		}
+		n++
+	}
+
+	if n != r.Num {
+		errmsg := fmt.Sprintf("Files should begin with %d newline(s) but %d newline(s)", r.Num, n)
+		res.AddReport(0, 0, errmsg)
+	}
+
+	return res, nil
+}
+
 func countFirstNewlines(s []byte) int {
 	n := 0
 
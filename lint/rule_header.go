@@ -0,0 +1,350 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var metadataHeaderRule = &MetaData{
+	Name:        "header",
+	Description: "This rule enforces files to begin with the header such as a license banner. The header is written as a template, in which {year} matches any years like 2017 or 2017-2024, or as a regular expression. The comment markers of the header are decided by the file type. Shebang lines are skipped.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Options: map[string]interface{}{"template": "SPDX-License-Identifier: MIT"}, Source: "package main\n", FileType: "go"},
+		{Options: map[string]interface{}{"template": "Copyright Example Inc. All rights reserved."}, Source: "#!/bin/sh\n# Copyright Example Inc.\necho hello\n", FileType: "shell"},
+	},
+	Options: []*Option{
+		{
+			Name:        "template",
+			Type:        StringOption,
+			Description: "the header without comment markers, in which {year} is replaced with the current year when it is inserted",
+			Default:     "",
+		},
+		{
+			Name:        "pattern",
+			Type:        StringOption,
+			Description: "the regular expression which the beginning of files must match including comment markers, instead of template. template is still used to insert the header",
+			Default:     "",
+		},
+		{
+			Name:        "comment-style",
+			Type:        StringOption,
+			Description: "the comment markers of the header, slash (//), hash (#), block (/* */), html (<!-- -->), none or auto to decide by the file type",
+			Default:     "auto",
+			Enum:        []string{"auto", "slash", "hash", "block", "html", "none"},
+		},
+	},
+
+	// this rule should be called after first-newline which removes empty
+	// lines at the beginning of files, and the header is inserted after the
+	// remaining empty lines so that first-newline with num > 0 is satisfied.
+	// It should be called before no-multiple-empty-lines which fixes the
+	// empty lines around the inserted header
+	rank: 4,
+}
+
+// commentStyle is the markers of comments. The lines of the header are
+// prefixed with line, and enclosed with begin and end if they are not empty.
+type commentStyle struct {
+	begin string
+	line  string
+	end   string
+}
+
+var commentStyles = map[string]*commentStyle{
+	"slash": {line: "// "},
+	"hash":  {line: "# "},
+	"block": {begin: "/*", line: " * ", end: " */"},
+	"html":  {begin: "<!--", end: "-->"},
+	"none":  {},
+}
+
+// fileTypeCommentStyles is the comment styles used when comment-style is auto.
+// The files of the other types are not linted.
+var fileTypeCommentStyles = map[string]string{
+	"css":        "block",
+	"dockerfile": "hash",
+	"go":         "slash",
+	"html":       "html",
+	"javascript": "slash",
+	"makefile":   "hash",
+	"markdown":   "html",
+	"perl":       "hash",
+	"python":     "hash",
+	"ruby":       "hash",
+	"shell":      "hash",
+	"text":       "none",
+	"toml":       "hash",
+	"typescript": "slash",
+	"yaml":       "hash",
+}
+
+// headerLikePattern matches the existing header to be replaced, which is the
+// comment at the beginning of files about the copyright or the license.
+var headerLikePattern = regexp.MustCompile(`(?i)copyright|license|spdx`)
+
+const yearPattern = `\d{4}(?:\s*[-,]\s*\d{4})*`
+
+type HeaderRule struct {
+	// Template is the lines of the header without comment markers
+	Template []string
+
+	// Pattern is the regular expression of the header used instead of
+	// Template, or nil
+	Pattern *regexp.Regexp
+
+	// anchored is Pattern matching only at the beginning
+	anchored *regexp.Regexp
+
+	// Style is the name of the comment style, or "auto"
+	Style string
+
+	// Year is the year inserted into the header, or 0 to use the current year
+	Year int
+
+	ctx *Context
+}
+
+func NewHeaderRule(ops map[string]interface{}) (Rule, error) {
+	rule := &HeaderRule{}
+	ops = withDefaults(metadataHeaderRule, ops)
+
+	if v, ok := ops["template"]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("header.template is only allow strings: %v", v)
+		}
+		if s = strings.TrimRight(s, "\n"); s != "" {
+			rule.Template = strings.Split(s, "\n")
+		}
+	}
+
+	if v, ok := ops["pattern"]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("header.pattern is only allow strings: %v", v)
+		}
+		if s != "" {
+			re, err := regexp.Compile(s)
+			if err != nil {
+				return nil, fmt.Errorf("header.pattern is invalid: %v", err)
+			}
+			rule.Pattern = re
+			rule.anchored = regexp.MustCompile(`\A(?:` + s + `)`)
+		}
+	}
+
+	if rule.Template == nil && rule.Pattern == nil {
+		return nil, fmt.Errorf("header.template or header.pattern is required")
+	}
+
+	if v, ok := ops["comment-style"]; ok {
+		s, _ := v.(string)
+		s = strings.ToLower(s)
+		if _, ok := commentStyles[s]; !ok && s != "auto" {
+			return nil, fmt.Errorf("header.comment-style is invalid: %v", v)
+		}
+		rule.Style = s
+	}
+
+	return rule, nil
+}
+
+func (r *HeaderRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewHeaderRule(ops)
+}
+
+func (r *HeaderRule) MetaData() *MetaData {
+	return metadataHeaderRule
+}
+
+func (r *HeaderRule) SetContext(ctx *Context) {
+	r.ctx = ctx
+}
+
+// commentStyle returns the comment style of the file, or nil if the file
+// should not be linted.
+func (r *HeaderRule) commentStyle() *commentStyle {
+	name := r.Style
+	if name == "auto" || name == "" {
+		if r.ctx == nil {
+			return nil
+		}
+		name = fileTypeCommentStyles[r.ctx.FileType]
+	}
+	return commentStyles[name]
+}
+
+func (r *HeaderRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+
+	style := r.commentStyle()
+	if style == nil {
+		res.Set(s)
+		return res, nil
+	}
+
+	linebreak := detectLinebreakStyle(s)
+	ls := bytes.Split(s, linebreak)
+
+	// the header begins after the BOM, the shebang and the empty lines
+	start := 0
+	if bytes.HasPrefix(bytes.TrimPrefix(ls[0], UTF8BOMs), []byte("#!")) {
+		start++
+	}
+	for start < len(ls)-1 && isBlankLine(ls[start]) {
+		start++
+	}
+	prefix := []byte{}
+	if start == 0 && bytes.HasPrefix(ls[0], UTF8BOMs) {
+		prefix = UTF8BOMs
+	}
+
+	rest := bytes.Join(ls[start:], linebreak)
+	rest = bytes.TrimPrefix(rest, prefix)
+	if r.match(style, rest, linebreak) {
+		res.Set(s)
+		return res, nil
+	}
+
+	// the existing comment is replaced only if it looks like a header
+	end := start
+	if n := style.commentLines(ls[start:], prefix); n > 0 && headerLikePattern.Match(bytes.Join(ls[start:start+n], linebreak)) {
+		end = start + n
+	}
+
+	msg := "Header is missing"
+	switch {
+	case r.Pattern != nil && end > start:
+		msg = fmt.Sprintf("Header doesn't match the pattern %s", r.Pattern)
+	case end > start:
+		msg = "Header doesn't match the template"
+	}
+
+	// the header can't be fixed without the template
+	if r.Template == nil {
+		res.AddUnfixableReport(start+1, 0, msg)
+		res.Set(s)
+		return res, nil
+	}
+	res.AddReport(start+1, 0, msg)
+
+	header := style.render(r.Template, r.year())
+	header[0] = append(append([]byte{}, prefix...), header[0]...)
+	if end == start && !isBlankLine(ls[end]) {
+		// separate the inserted header from the content
+		header = append(header, []byte{})
+	}
+	if end == start && len(prefix) > 0 {
+		ls[start] = bytes.TrimPrefix(ls[start], prefix)
+	}
+
+	fixed := make([][]byte, 0, len(ls)+len(header))
+	fixed = append(fixed, ls[:start]...)
+	fixed = append(fixed, header...)
+	fixed = append(fixed, ls[end:]...)
+	res.Set(bytes.Join(fixed, linebreak))
+
+	return res, nil
+}
+
+func (r *HeaderRule) year() int {
+	if r.Year != 0 {
+		return r.Year
+	}
+	return time.Now().Year()
+}
+
+// match reports whether s begins with the header.
+func (r *HeaderRule) match(style *commentStyle, s []byte, linebreak []byte) bool {
+	if r.Pattern != nil {
+		return r.anchored.Match(s)
+	}
+
+	ls := bytes.Split(s, linebreak)
+	for i, l := range style.render(r.Template, 0) {
+		if i >= len(ls) || !templateLinePattern(string(l)).Match(bytes.TrimRight(ls[i], " \t")) {
+			return false
+		}
+	}
+	return true
+}
+
+// templateLinePattern returns the regular expression of the line of the
+// rendered template, in which {year} matches years.
+func templateLinePattern(l string) *regexp.Regexp {
+	parts := strings.Split(l, "{year}")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	return regexp.MustCompile(`^` + strings.Join(parts, yearPattern) + `$`)
+}
+
+// render returns the lines of the header with comment markers. {year} is
+// replaced with the year unless it is 0.
+func (style *commentStyle) render(template []string, year int) [][]byte {
+	var ls [][]byte
+	if style.begin != "" {
+		ls = append(ls, []byte(style.begin))
+	}
+	for _, t := range template {
+		if year != 0 {
+			t = strings.Replace(t, "{year}", strconv.Itoa(year), -1)
+		}
+		ls = append(ls, []byte(strings.TrimRight(style.line+t, " \t")))
+	}
+	if style.end != "" {
+		ls = append(ls, []byte(style.end))
+	}
+	return ls
+}
+
+// commentLines returns the number of the lines of the comment at the
+// beginning of ls, or 0 if ls doesn't begin with a comment.
+func (style *commentStyle) commentLines(ls [][]byte, prefix []byte) int {
+	if len(ls) == 0 {
+		return 0
+	}
+	first := bytes.TrimPrefix(ls[0], prefix)
+
+	if style.begin != "" {
+		first = bytes.TrimLeft(first, " \t")
+		if !bytes.HasPrefix(first, []byte(style.begin)) {
+			return 0
+		}
+		end := []byte(strings.TrimSpace(style.end))
+		for i, l := range ls {
+			if i == 0 {
+				l = first[len(style.begin):]
+			}
+			if bytes.Contains(l, end) {
+				return i + 1
+			}
+		}
+		return 0
+	}
+
+	marker := []byte(strings.TrimSpace(style.line))
+	if len(marker) == 0 {
+		return 0
+	}
+	n := 0
+	for i, l := range ls {
+		if i == 0 {
+			l = first
+		}
+		if !bytes.HasPrefix(bytes.TrimLeft(l, " \t"), marker) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+func init() {
+	definedRules.Set(&HeaderRule{})
+}
@@ -0,0 +1,176 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderRule_Lint(t *testing.T) {
+	const license = "Copyright {year} Example Inc.\n\nLicensed under the MIT License."
+
+	tests := []struct {
+		ops      map[string]interface{}
+		fileType string
+		src      string
+		want     string
+		reports  []string
+	}{
+		{
+			ops:      map[string]interface{}{"template": license},
+			fileType: "go",
+			src:      "// Copyright 2017-2018 Example Inc.\n//\n// Licensed under the MIT License.\n\npackage main\n",
+			want:     "// Copyright 2017-2018 Example Inc.\n//\n// Licensed under the MIT License.\n\npackage main\n",
+			reports:  []string{},
+		},
+		{
+			ops:      map[string]interface{}{"template": license},
+			fileType: "go",
+			src:      "// Package main is a command.\npackage main\n",
+			want:     "// Copyright 2018 Example Inc.\n//\n// Licensed under the MIT License.\n\n// Package main is a command.\npackage main\n",
+			reports:  []string{"1:0: Header is missing"},
+		},
+		{
+			// the outdated header is replaced
+			ops:      map[string]interface{}{"template": license},
+			fileType: "python",
+			src:      "#!/usr/bin/env python\r\n# Copyright 2015 Example Inc.\r\n\r\nimport os\r\n",
+			want:     "#!/usr/bin/env python\r\n# Copyright 2018 Example Inc.\r\n#\r\n# Licensed under the MIT License.\r\n\r\nimport os\r\n",
+			reports:  []string{"2:0: Header doesn't match the template"},
+		},
+		{
+			ops:      map[string]interface{}{"template": license},
+			fileType: "css",
+			src:      "/* Copyright Someone */\na {}\n",
+			want:     "/*\n * Copyright 2018 Example Inc.\n *\n * Licensed under the MIT License.\n */\na {}\n",
+			reports:  []string{"1:0: Header doesn't match the template"},
+		},
+		{
+			ops:      map[string]interface{}{"template": "License: MIT"},
+			fileType: "markdown",
+			src:      "\xef\xbb\xbf# Title\n",
+			want:     "\xef\xbb\xbf<!--\nLicense: MIT\n-->\n\n# Title\n",
+			reports:  []string{"1:0: Header is missing"},
+		},
+		{
+			// the header is inserted after the empty lines left by
+			// first-newline
+			ops:      map[string]interface{}{"template": "License: MIT", "comment-style": "hash"},
+			fileType: "",
+			src:      "\na: 1\n",
+			want:     "\n# License: MIT\n\na: 1\n",
+			reports:  []string{"2:0: Header is missing"},
+		},
+		{
+			// unknown file types are not linted with auto style
+			ops:      map[string]interface{}{"template": "License: MIT"},
+			fileType: "json",
+			src:      "{}\n",
+			want:     "{}\n",
+			reports:  []string{},
+		},
+		{
+			ops:      map[string]interface{}{"pattern": `# Copyright \d{4} .+\n`},
+			fileType: "shell",
+			src:      "#!/bin/sh\n# Copyright 2018 Anyone\necho\n",
+			want:     "#!/bin/sh\n# Copyright 2018 Anyone\necho\n",
+			reports:  []string{},
+		},
+		{
+			ops:      map[string]interface{}{"pattern": `# Copyright \d{4} .+\n`},
+			fileType: "shell",
+			src:      "#!/bin/sh\n# copyright\necho\n",
+			want:     "#!/bin/sh\n# copyright\necho\n",
+			reports:  []string{`2:0: Header doesn't match the pattern # Copyright \d{4} .+\n`},
+		},
+		{
+			ops:      map[string]interface{}{"pattern": `# Copyright \d{4} .+\n`, "template": "Copyright {year} Example Inc."},
+			fileType: "shell",
+			src:      "echo\n",
+			want:     "# Copyright 2018 Example Inc.\n\necho\n",
+			reports:  []string{"1:0: Header is missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		rule, err := NewHeaderRule(tt.ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rule.(*HeaderRule).Year = 2018
+		rule.(ContextRule).SetContext(&Context{FileType: tt.fileType})
+
+		got, err := rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.want, string(got.Fixed), "%q", tt.src)
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports, "%q", tt.src)
+
+		// the fixed source has the header
+		got, err = rule.Lint(got.Fixed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := tt.ops["template"]; ok {
+			assert.Empty(t, got.Reports, "%q", tt.want)
+		}
+	}
+}
+
+// header rule should be called after first-newline rule and before
+// no-multiple-empty-lines rule, and their fixes should be stable
+func TestHeaderRule_FirstNewline(t *testing.T) {
+	for _, num := range []int{0, 1} {
+		header := &HeaderRule{Template: []string{"License: MIT"}, Style: "hash", Year: 2018}
+		rules := newRankedRules([]Rule{
+			&NoMultipleEmptyLinesRule{Max: 1, MaxBOF: num},
+			header,
+			&FirstNewlineRule{Num: num},
+		})
+
+		var names []string
+		for _, rule := range rules {
+			names = append(names, rule.MetaData().Name)
+		}
+		assert.Equal(t, []string{"first-newline", "header", "no-multiple-empty-lines"}, names)
+
+		src := []byte("\n\na: 1\n\n\n\nb: 2\n")
+		for i := 0; i < 2; i++ {
+			reports := 0
+			for _, rule := range rules {
+				res, err := rule.Lint(src)
+				if err != nil {
+					t.Fatal(err)
+				}
+				reports += len(res.Reports)
+				src = res.Fixed
+			}
+			if i == 1 {
+				assert.Equal(t, 0, reports, "num: %d", num)
+			}
+		}
+
+		if num == 0 {
+			assert.Equal(t, "# License: MIT\n\na: 1\n\nb: 2\n", string(src))
+		} else {
+			assert.Equal(t, "\n# License: MIT\n\na: 1\n\nb: 2\n", string(src))
+		}
+	}
+}
+
+func TestNewHeaderRule(t *testing.T) {
+	_, err := NewHeaderRule(nil)
+	assert.EqualError(t, err, "header.template or header.pattern is required")
+
+	_, err = NewHeaderRule(map[string]interface{}{"pattern": "("})
+	assert.EqualError(t, err, "header.pattern is invalid: error parsing regexp: missing closing ): `(`")
+
+	_, err = NewHeaderRule(map[string]interface{}{"template": "a", "comment-style": "semicolon"})
+	assert.EqualError(t, err, "header.comment-style is invalid: semicolon")
+}
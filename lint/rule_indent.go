@@ -0,0 +1,173 @@
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var metadataIndentRule = &MetaData{
+	Name:        "indent",
+	Description: "This rule enforces indentation with tabs or spaces.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Options: map[string]interface{}{"style": "space", "size": 2}, Source: "a\n\tb\n"},
+		{Options: map[string]interface{}{"style": "tab", "size": 4}, Source: "a\n    b\n"},
+	},
+	Options: []*Option{
+		{
+			Name:        "style",
+			Type:        StringOption,
+			Description: "the indent character, tab or space (tab indentation may be followed by less than `size` spaces for alignment)",
+			Default:     "space",
+			Enum:        []string{"tab", "space"},
+		},
+		{
+			Name:        "size",
+			Type:        IntOption,
+			Description: "the width of a tab, which is used to fix indentation",
+			Default:     4,
+			Min:         intRange(1),
+		},
+	},
+
+	// this rule should be called after linebreak rule
+	rank: 2,
+}
+
+var (
+	ErrUnknownIndentStyle = errors.New("unknown indent style")
+)
+
+type IndentStyle string
+
+const (
+	TabIndent   IndentStyle = "tab"
+	SpaceIndent IndentStyle = "space"
+)
+
+func NewIndentStyle(str string) (IndentStyle, error) {
+	switch s := IndentStyle(strings.ToLower(str)); s {
+	case TabIndent, SpaceIndent:
+		return s, nil
+	}
+	return "", ErrUnknownIndentStyle
+}
+
+type IndentRule struct {
+	Style IndentStyle
+	Size  int
+}
+
+func NewIndentRule(ops map[string]interface{}) (Rule, error) {
+	rule := &IndentRule{}
+	ops = withDefaults(metadataIndentRule, ops)
+
+	if v, ok := ops["style"]; ok {
+		value, _ := v.(string)
+		style, err := NewIndentStyle(value)
+		if err != nil {
+			return nil, fmt.Errorf("indent.style is invalid: %v: %v", err, v)
+		}
+		rule.Style = style
+	}
+
+	if v, ok := ops["size"]; ok {
+		if value, ok := v.(int); ok && value > 0 {
+			rule.Size = value
+		} else {
+			return nil, fmt.Errorf("indent.size is only allow positive numbers: %v", v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *IndentRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewIndentRule(ops)
+}
+
+func (r *IndentRule) MetaData() *MetaData {
+	return metadataIndentRule
+}
+
+func (r *IndentRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+
+	linebreak := detectLinebreakStyle(s)
+
+	ls := bytes.Split(s, linebreak)
+	for i, l := range ls {
+		indent := leadingSpace(l)
+		if r.isValid(l, indent) {
+			continue
+		}
+		res.AddReport(i+1, 0, r.message())
+		ls[i] = append(r.fix(indent), l[len(indent):]...)
+	}
+	res.Set(bytes.Join(ls, linebreak))
+
+	return res, nil
+}
+
+func (r *IndentRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+
+	if err := forEachLine(lr, func(l *Line) {
+		if !r.isValid(l.Text, leadingSpace(l.Text)) {
+			res.AddReport(l.Num, 0, r.message())
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *IndentRule) message() string {
+	if r.Style == TabIndent {
+		return "Expected indentation with tabs but found spaces"
+	}
+	return "Expected indentation with spaces but found tabs"
+}
+
+// isValid reports whether the indentation of the line follows the style.
+// Blank lines are always valid, they are the business of no-eol-space rule.
+func (r *IndentRule) isValid(line, indent []byte) bool {
+	if len(indent) == len(line) {
+		return true
+	}
+
+	if r.Style == SpaceIndent {
+		return bytes.IndexByte(indent, '\t') < 0
+	}
+
+	spaces := bytes.TrimLeft(indent, "\t")
+	return bytes.IndexByte(spaces, '\t') < 0 && len(spaces) < r.Size
+}
+
+// fix returns the indentation converted to the style keeping its width.
+func (r *IndentRule) fix(indent []byte) []byte {
+	width := 0
+	for _, c := range indent {
+		if c == '\t' {
+			width += r.Size - width%r.Size
+		} else {
+			width++
+		}
+	}
+
+	if r.Style == SpaceIndent {
+		return bytes.Repeat([]byte(" "), width)
+	}
+	return append(bytes.Repeat([]byte("\t"), width/r.Size), bytes.Repeat([]byte(" "), width%r.Size)...)
+}
+
+func leadingSpace(l []byte) []byte {
+	return l[:len(l)-len(bytes.TrimLeft(l, " \t"))]
+}
+
+func init() {
+	definedRules.Set(&IndentRule{})
+}
@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIndentStyle(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    IndentStyle
+		wanterr error
+	}{
+		{"tab", TabIndent, nil},
+		{"Space", SpaceIndent, nil},
+		{"tabs", "", ErrUnknownIndentStyle},
+	}
+
+	for _, tt := range tests {
+		got, err := NewIndentStyle(tt.key)
+		assert.Equal(t, tt.want, got)
+		assert.Equal(t, tt.wanterr, err)
+	}
+}
+
+func TestIndentRule_Lint(t *testing.T) {
+	tests := []struct {
+		rule    IndentRule
+		src     string
+		want    string
+		reports int
+	}{
+		{
+			rule:    IndentRule{Style: SpaceIndent, Size: 4},
+			src:     "a\n    b\n",
+			want:    "a\n    b\n",
+			reports: 0,
+		},
+		{
+			rule:    IndentRule{Style: SpaceIndent, Size: 4},
+			src:     "a\n\tb\n\t\tc\n  \td\n",
+			want:    "a\n    b\n        c\n    d\n",
+			reports: 3,
+		},
+		{
+			rule:    IndentRule{Style: SpaceIndent, Size: 2},
+			src:     "\ta\r\n",
+			want:    "  a\r\n",
+			reports: 1,
+		},
+		{
+			rule:    IndentRule{Style: TabIndent, Size: 4},
+			src:     "a\n\tb\n\t  c\n",
+			want:    "a\n\tb\n\t  c\n",
+			reports: 0,
+		},
+		{
+			rule:    IndentRule{Style: TabIndent, Size: 4},
+			src:     "a\n    b\n      c\n  \td\n",
+			want:    "a\n\tb\n\t  c\n\td\n",
+			reports: 3,
+		},
+		{
+			// blank lines are not checked
+			rule:    IndentRule{Style: TabIndent, Size: 4},
+			src:     "a\n    \n",
+			want:    "a\n    \n",
+			reports: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.rule.Lint([]byte(tt.src))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, string(got.Fixed), tt.src)
+		assert.Len(t, got.Reports, tt.reports, tt.src)
+	}
+}
+
+func TestNewIndentRule(t *testing.T) {
+	rule, err := NewIndentRule(map[string]interface{}{"style": "tab"})
+	assert.NoError(t, err)
+	assert.Equal(t, &IndentRule{Style: TabIndent, Size: 4}, rule)
+
+	_, err = NewIndentRule(map[string]interface{}{"style": "tabs"})
+	assert.Error(t, err)
+
+	_, err = NewIndentRule(map[string]interface{}{"size": 0})
+	assert.Error(t, err)
+}
@@ -8,7 +8,22 @@ import (
 )
 
 var metadataLinebreakRule = &MetaData{
-	Name: "linebreak",
+	Name:        "linebreak",
+	Description: "This rule enforces consistent linebreak style to Unix style (LF) or Windows style (CRLF).",
+	Fixable:     true,
+	Examples: []*Example{
+		{Options: map[string]interface{}{"style": "lf"}, Source: "a\r\nb\r\n"},
+		{Options: map[string]interface{}{"style": "crlf"}, Source: "a\nb\n"},
+	},
+	Options: []*Option{
+		{
+			Name:        "style",
+			Type:        StringOption,
+			Description: "the line endings, LF (`\\n`) or CRLF (`\\r\\n`)",
+			Default:     "lf",
+			Enum:        []string{"lf", "crlf"},
+		},
+	},
 
 	// this rule should called before all rules
 	rank: 0,
@@ -51,6 +66,7 @@ type LinebreakRule struct {
 
 func NewLinebreakRule(ops map[string]interface{}) (Rule, error) {
 	rule := &LinebreakRule{}
+	ops = withDefaults(metadataLinebreakRule, ops)
 
 	if v, ok := ops["style"]; ok {
 		if value, ok := v.(string); ok {
@@ -95,6 +111,30 @@ func (r *LinebreakRule) Lint(s []byte) (*Result, error) {
 	return res, nil
 }
 
+func (r *LinebreakRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+
+	formatTarget := UnixStyleLinebreak
+	if err := forEachLine(lr, func(l *Line) {
+		if bytes.Equal(l.Linebreak, WindowsStyleLinebreak) || bytes.IndexByte(l.Text, '\r') >= 0 {
+			formatTarget = WindowsStyleLinebreak
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(formatTarget, r.Style) {
+		errmsg := fmt.Sprintf(
+			`Expected linebreaks to be %s but found %s`,
+			r.Style,
+			formatTarget,
+		)
+		res.AddReport(0, 0, errmsg)
+	}
+
+	return res, nil
+}
+
 func detectLinebreakStyle(bs []byte) LinebreakStyle {
 	for _, b := range bs {
 		if b == '\r' {
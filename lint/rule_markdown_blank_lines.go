@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var metadataMarkdownNoMultipleBlankLinesRule = &MetaData{
+	Name:        "markdown-no-multiple-blank-lines",
+	Description: "This rule disallows multiple consecutive blank lines outside of code blocks in Markdown documents.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Source: "# title\n\n\nparagraph\n", FileType: "markdown"},
+	},
+	Options: []*Option{
+		{
+			Name:        "max",
+			Type:        IntOption,
+			Description: "the max number of consecutive blank lines",
+			Default:     1,
+			Min:         intRange(1),
+		},
+	},
+
+	// this rule should be called after markdown-heading-spacing which inserts
+	// blank lines
+	rank: 6,
+}
+
+type MarkdownNoMultipleBlankLinesRule struct {
+	markdownRule
+
+	Max int
+}
+
+func NewMarkdownNoMultipleBlankLinesRule(ops map[string]interface{}) (Rule, error) {
+	rule := &MarkdownNoMultipleBlankLinesRule{}
+	ops = withDefaults(metadataMarkdownNoMultipleBlankLinesRule, ops)
+
+	if v, ok := ops["max"]; ok {
+		if value, ok := v.(int); ok && value > 0 {
+			rule.Max = value
+		} else {
+			return nil, fmt.Errorf("markdown-no-multiple-blank-lines.max is only allow positive numbers: %v", v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *MarkdownNoMultipleBlankLinesRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewMarkdownNoMultipleBlankLinesRule(ops)
+}
+
+func (r *MarkdownNoMultipleBlankLinesRule) MetaData() *MetaData {
+	return metadataMarkdownNoMultipleBlankLinesRule
+}
+
+func (r *MarkdownNoMultipleBlankLinesRule) Lint(s []byte) (*Result, error) {
+	if !r.ctx.IsMarkdown() {
+		res := NewResult()
+		res.Set(s)
+		return res, nil
+	}
+
+	// the blank lines at the end of the file are the business of
+	// final-newline rule
+	rule := &NoMultipleEmptyLinesRule{Max: r.Max, MaxBOF: r.Max, ignoreEOF: true, ctx: r.ctx}
+	return rule.Lint(s)
+}
+
+func isBlankLine(l []byte) bool {
+	return len(bytes.TrimSpace(l)) == 0
+}
+
+func init() {
+	definedRules.Set(&MarkdownNoMultipleBlankLinesRule{})
+}
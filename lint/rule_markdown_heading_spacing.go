@@ -0,0 +1,93 @@
+package lint
+
+import (
+	"bytes"
+	"regexp"
+)
+
+var metadataMarkdownHeadingSpacingRule = &MetaData{
+	Name:        "markdown-heading-spacing",
+	Description: "This rule enforces blank lines around headings and a single space after # of headings in Markdown documents.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Source: "# title\nparagraph\n##  section\n", FileType: "markdown"},
+	},
+
+	// this rule should be called after first-newline which removes blank
+	// lines at the beginning of files
+	rank: 5,
+}
+
+var headingPattern = regexp.MustCompile(`^( {0,3}#{1,6})([ \t]+|$)`)
+
+type MarkdownHeadingSpacingRule struct {
+	markdownRule
+}
+
+func NewMarkdownHeadingSpacingRule(ops map[string]interface{}) (Rule, error) {
+	return &MarkdownHeadingSpacingRule{}, nil
+}
+
+func (r *MarkdownHeadingSpacingRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewMarkdownHeadingSpacingRule(ops)
+}
+
+func (r *MarkdownHeadingSpacingRule) MetaData() *MetaData {
+	return metadataMarkdownHeadingSpacingRule
+}
+
+func (r *MarkdownHeadingSpacingRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+	if !r.ctx.IsMarkdown() {
+		res.Set(s)
+		return res, nil
+	}
+
+	linebreak := detectLinebreakStyle(s)
+
+	ls := bytes.Split(s, linebreak)
+	kinds := markdownLineKinds(ls)
+
+	// the last element is not a line if the file ends with a line break
+	n := len(ls)
+	if n > 0 && len(ls[n-1]) == 0 {
+		n--
+	}
+
+	fixed := make([][]byte, 0, len(ls))
+	inserted := false
+	for i, l := range ls {
+		m := headingPattern.FindSubmatchIndex(l)
+		if i >= n || kinds[i] != TextRegion || m == nil {
+			fixed = append(fixed, l)
+			inserted = false
+			continue
+		}
+
+		if i > 0 && !inserted && !isBlankLine(ls[i-1]) && kinds[i-1] != FrontMatterRegion {
+			res.AddReport(i+1, 0, "Headings should be preceded by a blank line")
+			fixed = append(fixed, []byte{})
+		}
+
+		// "#  title" has extra spaces, and "#\ttitle" has a tab
+		if space := l[m[4]:m[5]]; len(space) > 0 && m[5] < len(l) && !bytes.Equal(space, []byte(" ")) {
+			res.AddReport(i+1, 0, "Headings should have a single space after #")
+			l = append(append(append([]byte{}, l[:m[3]]...), ' '), l[m[5]:]...)
+		}
+		fixed = append(fixed, l)
+		inserted = false
+
+		if i+1 < n && !isBlankLine(ls[i+1]) {
+			res.AddReport(i+1, 0, "Headings should be followed by a blank line")
+			fixed = append(fixed, []byte{})
+			inserted = true
+		}
+	}
+	res.Set(bytes.Join(fixed, linebreak))
+
+	return res, nil
+}
+
+func init() {
+	definedRules.Set(&MarkdownHeadingSpacingRule{})
+}
@@ -0,0 +1,131 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var metadataMarkdownListMarkerRule = &MetaData{
+	Name:        "markdown-list-marker",
+	Description: "This rule enforces consistent markers of unordered lists in Markdown documents.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Source: "- a\n* b\n+ c\n", FileType: "markdown"},
+		{Options: map[string]interface{}{"style": "asterisk"}, Source: "- a\n  - b\n", FileType: "markdown"},
+	},
+	Options: []*Option{
+		{
+			Name:        "style",
+			Type:        StringOption,
+			Description: "the list marker, dash (-), asterisk (*), plus (+) or consistent with the first list marker in the document",
+			Default:     "consistent",
+			Enum:        []string{"consistent", "dash", "asterisk", "plus"},
+		},
+	},
+	rank: 5,
+}
+
+var listMarkers = map[string]byte{
+	"dash":     '-',
+	"asterisk": '*',
+	"plus":     '+',
+}
+
+var listItemPattern = regexp.MustCompile(`^( *)([-*+])([ \t]|$)`)
+
+type MarkdownListMarkerRule struct {
+	markdownRule
+
+	// Marker is the list marker, or 0 to be consistent with the first one
+	Marker byte
+}
+
+func NewMarkdownListMarkerRule(ops map[string]interface{}) (Rule, error) {
+	rule := &MarkdownListMarkerRule{}
+	ops = withDefaults(metadataMarkdownListMarkerRule, ops)
+
+	if v, ok := ops["style"]; ok {
+		value, _ := v.(string)
+		value = strings.ToLower(value)
+		if marker, ok := listMarkers[value]; ok {
+			rule.Marker = marker
+		} else if value != "consistent" {
+			return nil, fmt.Errorf("markdown-list-marker.style is invalid: %v", v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *MarkdownListMarkerRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewMarkdownListMarkerRule(ops)
+}
+
+func (r *MarkdownListMarkerRule) MetaData() *MetaData {
+	return metadataMarkdownListMarkerRule
+}
+
+func (r *MarkdownListMarkerRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+	if !r.ctx.IsMarkdown() {
+		res.Set(s)
+		return res, nil
+	}
+
+	linebreak := detectLinebreakStyle(s)
+
+	ls := bytes.Split(s, linebreak)
+	kinds := markdownLineKinds(ls)
+	want := r.Marker
+	for i, l := range ls {
+		if kinds[i] != TextRegion || isThematicBreak(l) {
+			continue
+		}
+		m := listItemPattern.FindSubmatchIndex(l)
+		if m == nil {
+			continue
+		}
+
+		marker := l[m[4]]
+		if want == 0 {
+			want = marker
+		}
+		if marker == want {
+			continue
+		}
+		res.AddReport(i+1, 0, fmt.Sprintf("List marker should be %q but found %q", want, marker))
+		fixed := append([]byte{}, l...)
+		fixed[m[4]] = want
+		ls[i] = fixed
+	}
+	res.Set(bytes.Join(ls, linebreak))
+
+	return res, nil
+}
+
+// isThematicBreak reports whether the line is a thematic break like "* * *",
+// which looks like a list item.
+func isThematicBreak(l []byte) bool {
+	l = bytes.TrimSpace(l)
+	if len(l) < 3 || bytes.IndexByte([]byte("-*_"), l[0]) < 0 {
+		return false
+	}
+
+	n := 0
+	for _, c := range l {
+		switch c {
+		case l[0]:
+			n++
+		case ' ', '\t':
+		default:
+			return false
+		}
+	}
+	return n >= 3
+}
+
+func init() {
+	definedRules.Set(&MarkdownListMarkerRule{})
+}
@@ -0,0 +1,83 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownRules_Lint(t *testing.T) {
+	markdown := &Context{FileType: "markdown"}
+
+	tests := []struct {
+		rule    ContextRule
+		src     string
+		want    string
+		reports int
+	}{
+		{
+			rule:    &MarkdownListMarkerRule{},
+			src:     "- a\n* b\n  + c\n\n* * *\n***\n```\n* d\n```\n*e*\n",
+			want:    "- a\n- b\n  - c\n\n* * *\n***\n```\n* d\n```\n*e*\n",
+			reports: 2,
+		},
+		{
+			rule:    &MarkdownListMarkerRule{Marker: '*'},
+			src:     "- a\r\n-\r\n+ b\r\n",
+			want:    "* a\r\n*\r\n* b\r\n",
+			reports: 3,
+		},
+		{
+			rule:    &MarkdownNoMultipleBlankLinesRule{Max: 1},
+			src:     "a\n\n\n\nb\n \n\t\nc\n```\n\n\n```\n\n\n",
+			want:    "a\n\nb\n \nc\n```\n\n\n```\n\n\n",
+			reports: 2,
+		},
+		{
+			rule:    &MarkdownNoMultipleBlankLinesRule{Max: 2},
+			src:     "a\n\n\nb\n\n\n\nc",
+			want:    "a\n\n\nb\n\n\nc",
+			reports: 1,
+		},
+		{
+			rule:    &MarkdownHeadingSpacingRule{},
+			src:     "# a\nb\n## c\n### d\n\ne\n#### f\n",
+			want:    "# a\n\nb\n\n## c\n\n### d\n\ne\n\n#### f\n",
+			reports: 4,
+		},
+		{
+			rule:    &MarkdownHeadingSpacingRule{},
+			src:     "---\ntitle: a\n---\n#  a\n\n#\tb\n\n#c\n```\n# d\n```\n",
+			want:    "---\ntitle: a\n---\n# a\n\n# b\n\n#c\n```\n# d\n```\n",
+			reports: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt.rule.SetContext(markdown)
+		got, err := tt.rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.want, string(got.Fixed), "%s: %q", tt.rule.MetaData().Name, tt.src)
+		assert.Len(t, got.Reports, tt.reports, "%s: %q", tt.rule.MetaData().Name, tt.src)
+	}
+}
+
+// Markdown rules don't lint other files
+func TestMarkdownRules_Lint_NotMarkdown(t *testing.T) {
+	src := "* a\n- b\n# c\nd\n\n\n\ne\n"
+	for _, rule := range []ContextRule{
+		&MarkdownListMarkerRule{},
+		&MarkdownNoMultipleBlankLinesRule{Max: 1},
+		&MarkdownHeadingSpacingRule{},
+	} {
+		rule.SetContext(&Context{FileType: "text"})
+		got, err := rule.Lint([]byte(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, src, string(got.Fixed), rule.MetaData().Name)
+		assert.Empty(t, got.Reports, rule.MetaData().Name)
+	}
+}
@@ -0,0 +1,156 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+var metadataMaxSizeRule = &MetaData{
+	Name:        "max-size",
+	Description: "This rule limits the size and the number of lines of files, and reports minified files whose lines are too long on average. Files larger than bytes are rejected by their size without being read.",
+	Examples: []*Example{
+		{Options: map[string]interface{}{"bytes": 8}, Source: "0123456789\n"},
+		{Options: map[string]interface{}{"lines": 2}, Source: "a\nb\nc\n"},
+		{Options: map[string]interface{}{"minified": true, "minified-line-length": 10}, Source: "function f(a,b){return a+b}\n"},
+	},
+	Options: []*Option{
+		{
+			Name:        "bytes",
+			Type:        IntOption,
+			Description: "the max size of files in bytes, 0 means unlimited",
+			Default:     0,
+			Min:         intRange(0),
+		},
+		{
+			Name:        "lines",
+			Type:        IntOption,
+			Description: "the max number of lines of files, 0 means unlimited",
+			Default:     0,
+			Min:         intRange(0),
+		},
+		{
+			Name:        "minified",
+			Type:        BoolOption,
+			Description: "report the files whose average line length is longer than minified-line-length",
+			Default:     false,
+		},
+		{
+			Name:        "minified-line-length",
+			Type:        IntOption,
+			Description: "the average line length in bytes of minified files",
+			Default:     500,
+			Min:         intRange(1),
+		},
+	},
+}
+
+type MaxSizeRule struct {
+	Bytes              int
+	Lines              int
+	Minified           bool
+	MinifiedLineLength int
+}
+
+func NewMaxSizeRule(ops map[string]interface{}) (Rule, error) {
+	rule := &MaxSizeRule{}
+	ops = withDefaults(metadataMaxSizeRule, ops)
+
+	for name, p := range map[string]*int{
+		"bytes": &rule.Bytes,
+		"lines": &rule.Lines,
+	} {
+		v, ok := ops[name]
+		if !ok {
+			continue
+		}
+		if value, ok := v.(int); ok && value >= 0 {
+			*p = value
+		} else {
+			return nil, fmt.Errorf("max-size.%s is only allow non-negative numbers: %v", name, v)
+		}
+	}
+
+	if v, ok := ops["minified"]; ok {
+		if value, ok := v.(bool); ok {
+			rule.Minified = value
+		} else {
+			return nil, fmt.Errorf("max-size.minified is only allow boolean: %v", v)
+		}
+	}
+
+	if v, ok := ops["minified-line-length"]; ok {
+		if value, ok := v.(int); ok && value > 0 {
+			rule.MinifiedLineLength = value
+		} else {
+			return nil, fmt.Errorf("max-size.minified-line-length is only allow positive numbers: %v", v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *MaxSizeRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewMaxSizeRule(ops)
+}
+
+func (r *MaxSizeRule) MetaData() *MetaData {
+	return metadataMaxSizeRule
+}
+
+func (r *MaxSizeRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+	res.Set(s)
+
+	lines := bytes.Count(s, []byte("\n"))
+	if len(s) > 0 && s[len(s)-1] != '\n' {
+		lines++
+	}
+	r.report(res, len(s), lines)
+
+	return res, nil
+}
+
+func (r *MaxSizeRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+
+	size, lines := 0, 0
+	if err := forEachLine(lr, func(l *Line) {
+		size += l.Size
+		lines++
+	}); err != nil {
+		return nil, err
+	}
+	r.report(res, size, lines)
+
+	return res, nil
+}
+
+// LintStat reports the file larger than Bytes before reading it.
+func (r *MaxSizeRule) LintStat(fi os.FileInfo) (*Result, error) {
+	res := NewResult()
+	if r.Bytes > 0 && fi.Size() > int64(r.Bytes) {
+		res.AddReport(0, 0, r.bytesMessage(fi.Size()))
+	}
+	return res, nil
+}
+
+func (r *MaxSizeRule) report(res *Result, size, lines int) {
+	if r.Bytes > 0 && size > r.Bytes {
+		res.AddReport(0, 0, r.bytesMessage(int64(size)))
+	}
+	if r.Lines > 0 && lines > r.Lines {
+		res.AddReport(0, 0, fmt.Sprintf("File has %d lines, at most %d allowed", lines, r.Lines))
+	}
+	if r.Minified && lines > 0 && size/lines > r.MinifiedLineLength {
+		res.AddReport(0, 0, fmt.Sprintf("File looks minified, lines are %d bytes long on average", size/lines))
+	}
+}
+
+func (r *MaxSizeRule) bytesMessage(size int64) string {
+	return fmt.Sprintf("File is %d bytes, at most %d allowed", size, r.Bytes)
+}
+
+func init() {
+	definedRules.Set(&MaxSizeRule{})
+}
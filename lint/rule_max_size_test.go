@@ -0,0 +1,164 @@
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxSizeRule_Lint(t *testing.T) {
+	tests := []struct {
+		rule    *MaxSizeRule
+		src     string
+		reports []string
+	}{
+		{
+			rule:    &MaxSizeRule{Bytes: 4},
+			src:     "abc\n",
+			reports: []string{},
+		},
+		{
+			rule:    &MaxSizeRule{Bytes: 4},
+			src:     "abcd\n",
+			reports: []string{"0:0: File is 5 bytes, at most 4 allowed"},
+		},
+		{
+			rule:    &MaxSizeRule{Lines: 2},
+			src:     "a\n\nb",
+			reports: []string{"0:0: File has 3 lines, at most 2 allowed"},
+		},
+		{
+			rule:    &MaxSizeRule{Lines: 2},
+			src:     "a\n\n",
+			reports: []string{},
+		},
+		{
+			rule:    &MaxSizeRule{Minified: true, MinifiedLineLength: 10},
+			src:     "short\n" + strings.Repeat("a", 30) + "\n",
+			reports: []string{"0:0: File looks minified, lines are 18 bytes long on average"},
+		},
+		{
+			rule:    &MaxSizeRule{Minified: true, MinifiedLineLength: 10},
+			src:     "short\n\n\n" + strings.Repeat("a", 30) + "\n",
+			reports: []string{},
+		},
+		{
+			rule:    &MaxSizeRule{Minified: true, MinifiedLineLength: 10},
+			src:     "",
+			reports: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.src, string(got.Fixed))
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports, "%q", tt.src)
+	}
+}
+
+func TestMaxSizeRule_LintStream(t *testing.T) {
+	rule := &MaxSizeRule{Bytes: 10, Lines: 1, Minified: true, MinifiedLineLength: 100}
+
+	// the size of the lines longer than maxLineLength is counted
+	src := strings.Repeat("a", maxLineLength*2) + "\r\nb"
+	got, err := rule.LintStream(NewLineReader(strings.NewReader(src)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reports := []string{}
+	for _, r := range got.Reports {
+		reports = append(reports, r.String())
+	}
+	assert.Equal(t, []string{
+		"0:0: File is 131075 bytes, at most 10 allowed",
+		"0:0: File has 2 lines, at most 1 allowed",
+		"0:0: File looks minified, lines are 65537 bytes long on average",
+	}, reports)
+}
+
+func TestNewLinter_StatRule(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filelint-lint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "a.txt")
+	if err := ioutil.WriteFile(file, []byte("abc \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rules   []Rule
+		stream  bool
+		reports []string
+		canFix  bool
+	}{
+		{
+			rules:   []Rule{&NoEOLSpaceRule{}, &MaxSizeRule{Bytes: 5}},
+			reports: []string{"1:4: Trailing spaces/tabs at the end of lines are disallowed"},
+			canFix:  true,
+		},
+		{
+			// the other rules are not run for the rejected file
+			rules:   []Rule{&NoEOLSpaceRule{}, &MaxSizeRule{Bytes: 4}},
+			reports: []string{"0:0: File is 5 bytes, at most 4 allowed"},
+			canFix:  false,
+		},
+		{
+			rules:   []Rule{&NoEOLSpaceRule{}, &MaxSizeRule{Bytes: 4}},
+			stream:  true,
+			reports: []string{"0:0: File is 5 bytes, at most 4 allowed"},
+			canFix:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		var linter *Linter
+		if tt.stream {
+			linter = NewStreamLinter(file, "", tt.rules)
+		} else {
+			linter, err = NewLinter(file, "", tt.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		assert.Equal(t, tt.canFix, linter.CanFix())
+
+		result, err := linter.Lint()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reports := []string{}
+		for _, r := range result.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports)
+	}
+
+	rejected, err := NewStatLinter(file, []Rule{&MaxSizeRule{Bytes: 5}})
+	assert.NoError(t, err)
+	assert.Nil(t, rejected)
+}
+
+func TestNewMaxSizeRule(t *testing.T) {
+	_, err := NewMaxSizeRule(map[string]interface{}{"bytes": -1})
+	assert.EqualError(t, err, "max-size.bytes is only allow non-negative numbers: -1")
+
+	_, err = NewMaxSizeRule(map[string]interface{}{"minified-line-length": 0})
+	assert.EqualError(t, err, "max-size.minified-line-length is only allow positive numbers: 0")
+}
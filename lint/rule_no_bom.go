@@ -3,8 +3,13 @@ package lint
 import "bytes"
 
 var metadataNoBOMRule = &MetaData{
-	Name: "no-bom",
-	rank: 5,
+	Name:        "no-bom",
+	Description: "This rule enforces no byte order marks (BOM) of UTF-8 to any text files.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Source: "\ufeffa\n"},
+	},
+	rank: 7,
 }
 
 type NoBOMRule struct{}
@@ -36,6 +41,17 @@ func (r *NoBOMRule) Lint(s []byte) (*Result, error) {
 	return res, nil
 }
 
+func (r *NoBOMRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+	errmsg := "Byte order mark is disallowed"
+
+	if bytes.HasPrefix(lr.Peek(len(UTF8BOMs)), UTF8BOMs) {
+		res.AddReport(0, 0, errmsg)
+	}
+
+	return res, nil
+}
+
 var UTF8BOMs = []byte{0xEF, 0xbb, 0xbf}
 
 func init() {
@@ -0,0 +1,201 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var metadataNoConflictMarkersRule = &MetaData{
+	Name:        "no-conflict-markers",
+	Description: "This rule disallows unresolved merge conflicts, which are blocks from <<<<<<< to >>>>>>> at the beginning of lines including ||||||| sections of diff3 style. Debris of merging and debugging such as *.orig files and \"DO NOT COMMIT\" comments can be also disallowed.",
+	Examples: []*Example{
+		{Source: "a\n<<<<<<< HEAD\nb\n=======\nc\n>>>>>>> feature\n"},
+		{Options: map[string]interface{}{"debris": []string{"DO NOT COMMIT"}}, Source: "debug = true # DO NOT COMMIT\n"},
+	},
+	Options: []*Option{
+		{
+			Name:        "debris",
+			Type:        StringsOption,
+			Description: `the regular expressions of debris in lines such as "DO NOT (COMMIT|MERGE)"`,
+			Default:     []string{},
+		},
+		{
+			Name:        "debris-files",
+			Type:        StringsOption,
+			Description: `the patterns of debris file names such as "*.orig" and "*.rej"`,
+			Default:     []string{},
+		},
+	},
+	rank: 1,
+}
+
+var (
+	conflictBeginPattern = regexp.MustCompile(`^<{7}(?:[ \t]|$)`)
+	conflictEndPattern   = regexp.MustCompile(`^>{7}(?:[ \t]|$)`)
+)
+
+type NoConflictMarkersRule struct {
+	// Debris is the patterns of debris in lines
+	Debris []*regexp.Regexp
+
+	// DebrisFiles is the patterns of debris file names
+	DebrisFiles []string
+
+	ctx *Context
+}
+
+func NewNoConflictMarkersRule(ops map[string]interface{}) (Rule, error) {
+	rule := &NoConflictMarkersRule{}
+	ops = withDefaults(metadataNoConflictMarkersRule, ops)
+
+	if v, ok := ops["debris"]; ok {
+		patterns, err := toStrings(v)
+		if err != nil {
+			return nil, fmt.Errorf("no-conflict-markers.debris is invalid: %v", err)
+		}
+		for _, p := range patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("no-conflict-markers.debris is invalid: %v", err)
+			}
+			rule.Debris = append(rule.Debris, re)
+		}
+	}
+
+	if v, ok := ops["debris-files"]; ok {
+		patterns, err := toStrings(v)
+		if err != nil {
+			return nil, fmt.Errorf("no-conflict-markers.debris-files is invalid: %v", err)
+		}
+		for _, p := range patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, fmt.Errorf("no-conflict-markers.debris-files is invalid: %q: %v", p, err)
+			}
+		}
+		rule.DebrisFiles = patterns
+	}
+
+	return rule, nil
+}
+
+func (r *NoConflictMarkersRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewNoConflictMarkersRule(ops)
+}
+
+func (r *NoConflictMarkersRule) MetaData() *MetaData {
+	return metadataNoConflictMarkersRule
+}
+
+func (r *NoConflictMarkersRule) SetContext(ctx *Context) {
+	r.ctx = ctx
+}
+
+func (r *NoConflictMarkersRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+
+	sc := &conflictScanner{rule: r, res: res}
+	for i, l := range bytes.Split(s, UnixStyleLinebreak) {
+		sc.scan(i+1, l)
+	}
+	sc.finish()
+	res.Set(s)
+
+	return res, nil
+}
+
+func (r *NoConflictMarkersRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+
+	sc := &conflictScanner{rule: r, res: res}
+	if err := forEachLine(lr, func(l *Line) {
+		sc.scan(l.Num, l.Text)
+	}); err != nil {
+		return nil, err
+	}
+	sc.finish()
+
+	return res, nil
+}
+
+// debrisFile returns the pattern matching the name of the file being linted,
+// or empty if it is not debris. The patterns including slashes are matched
+// with the whole path, and the others are matched with the base name.
+func (r *NoConflictMarkersRule) debrisFile() string {
+	if r.ctx == nil || r.ctx.Filename == "" {
+		return ""
+	}
+
+	name := filepath.ToSlash(r.ctx.Filename)
+	for _, p := range r.DebrisFiles {
+		target := path.Base(name)
+		if strings.Contains(p, "/") {
+			target = name
+		}
+		if ok, _ := path.Match(p, target); ok {
+			return p
+		}
+	}
+	return ""
+}
+
+// conflictScanner finds the conflict blocks and debris line by line.
+type conflictScanner struct {
+	rule *NoConflictMarkersRule
+	res  *Result
+
+	// begin is the line number of the current <<<<<<< marker, or 0 if it is
+	// not in a conflict block
+	begin int
+}
+
+func (sc *conflictScanner) scan(num int, l []byte) {
+	l = bytes.TrimSuffix(l, []byte("\r"))
+
+	// ||||||| and ======= alone are not reported since ======= is also used
+	// as the underlines of headings
+	switch {
+	case conflictBeginPattern.Match(l):
+		if sc.begin > 0 {
+			sc.res.AddReport(sc.begin, 0, `Merge conflict marker "<<<<<<<" is left without ">>>>>>>"`)
+		}
+		sc.begin = num
+	case conflictEndPattern.Match(l):
+		if sc.begin > 0 {
+			sc.res.AddReport(sc.begin, 0, fmt.Sprintf("Unresolved merge conflict (lines %d-%d)", sc.begin, num))
+		} else {
+			sc.res.AddReport(num, 0, `Merge conflict marker ">>>>>>>" is left without "<<<<<<<"`)
+		}
+		sc.begin = 0
+	}
+
+	for _, re := range sc.rule.Debris {
+		for _, m := range re.FindAllIndex(l, -1) {
+			col := utf8.RuneCount(l[:m[0]]) + 1
+			sc.res.AddReport(num, col, fmt.Sprintf("Debris %q is left", l[m[0]:m[1]]))
+		}
+	}
+}
+
+func (sc *conflictScanner) finish() {
+	if sc.begin > 0 {
+		sc.res.AddReport(sc.begin, 0, `Merge conflict marker "<<<<<<<" is left without ">>>>>>>"`)
+	}
+	if p := sc.rule.debrisFile(); p != "" {
+		sc.res.AddReport(0, 0, fmt.Sprintf("Debris file matching %q is left", p))
+	}
+
+	// the conflict blocks are reported after scanning their ends
+	sort.SliceStable(sc.res.Reports, func(i, j int) bool {
+		return sc.res.Reports[i].Line() < sc.res.Reports[j].Line()
+	})
+}
+
+func init() {
+	definedRules.Set(&NoConflictMarkersRule{})
+}
@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoConflictMarkersRule_Lint(t *testing.T) {
+	tests := []struct {
+		ops     map[string]interface{}
+		file    string
+		src     string
+		reports []string
+	}{
+		{
+			src:     "a\n<<<<<<< HEAD\nb\n=======\nc\n>>>>>>> feature\nd\n",
+			reports: []string{"2:0: Unresolved merge conflict (lines 2-6)"},
+		},
+		{
+			// diff3 style with the base section
+			src:     "<<<<<<< ours\r\nb\r\n||||||| base\r\na\r\n=======\r\nc\r\n>>>>>>> theirs\r\n",
+			reports: []string{"1:0: Unresolved merge conflict (lines 1-7)"},
+		},
+		{
+			src: "<<<<<<<\na\n<<<<<<< HEAD\nb\n=======\n>>>>>>>\n>>>>>>> x\n<<<<<<< y\n",
+			reports: []string{
+				`1:0: Merge conflict marker "<<<<<<<" is left without ">>>>>>>"`,
+				"3:0: Unresolved merge conflict (lines 3-6)",
+				`7:0: Merge conflict marker ">>>>>>>" is left without "<<<<<<<"`,
+				`8:0: Merge conflict marker "<<<<<<<" is left without ">>>>>>>"`,
+			},
+		},
+		{
+			// markers must be at the beginning of lines, and headings are not
+			// conflicts
+			src:     "Title\n=======\n  <<<<<<< HEAD\n<<<<<<<< a\n>>>>>>>>\n",
+			reports: []string{},
+		},
+		{
+			ops: map[string]interface{}{"debris": []interface{}{"DO NOT (COMMIT|MERGE)"}},
+			src: "<<<<<<< HEAD\n\u3042 // DO NOT COMMIT\n=======\n>>>>>>> x\nDO NOT MERGE\n",
+			reports: []string{
+				"1:0: Unresolved merge conflict (lines 1-4)",
+				`2:6: Debris "DO NOT COMMIT" is left`,
+				`5:1: Debris "DO NOT MERGE" is left`,
+			},
+		},
+		{
+			ops:     map[string]interface{}{"debris-files": []interface{}{"*.orig", "tmp/*"}},
+			file:    "src/main.go.orig",
+			src:     "a\n",
+			reports: []string{`0:0: Debris file matching "*.orig" is left`},
+		},
+		{
+			ops:     map[string]interface{}{"debris-files": []interface{}{"*.orig", "tmp/*"}},
+			file:    "src/tmp/a.txt",
+			src:     "a\n",
+			reports: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		rule, err := NewNoConflictMarkersRule(tt.ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rule.(ContextRule).SetContext(&Context{Filename: tt.file})
+
+		got, err := rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.src, string(got.Fixed))
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports, "%q", tt.src)
+	}
+}
+
+func TestNewNoConflictMarkersRule(t *testing.T) {
+	_, err := NewNoConflictMarkersRule(map[string]interface{}{"debris": []interface{}{"("}})
+	assert.EqualError(t, err, "no-conflict-markers.debris is invalid: error parsing regexp: missing closing ): `(`")
+
+	_, err = NewNoConflictMarkersRule(map[string]interface{}{"debris-files": []interface{}{"[a"}})
+	assert.EqualError(t, err, `no-conflict-markers.debris-files is invalid: "[a": syntax error in pattern`)
+}
@@ -0,0 +1,247 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var metadataNoMultipleEmptyLinesRule = &MetaData{
+	Name:        "no-multiple-empty-lines",
+	Description: "This rule limits the number of consecutive empty lines. Lines with only whitespaces are also empty. The empty lines in the code blocks and the front matter of Markdown documents are not counted.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Options: map[string]interface{}{"max": 1}, Source: "a\n\n\n\nb\n"},
+		{Options: map[string]interface{}{"max-bof": 0, "max-eof": 1}, Source: "\na\n\n\n"},
+	},
+	Options: []*Option{
+		{
+			Name:        "max",
+			Type:        IntOption,
+			Description: "the max number of consecutive empty lines",
+			Default:     1,
+			Min:         intRange(0),
+		},
+		{
+			Name:        "max-bof",
+			Type:        IntOption,
+			Description: "the max number of empty lines at the beginning of files",
+			Default:     0,
+			Min:         intRange(0),
+		},
+		{
+			Name:        "max-eof",
+			Type:        IntOption,
+			Description: "the max number of empty lines at the end of files, not counting the line break of the last line",
+			Default:     0,
+			Min:         intRange(0),
+		},
+	},
+
+	// this rule should be called after header which inserts lines, and
+	// before final-newline
+	rank: 5,
+}
+
+type NoMultipleEmptyLinesRule struct {
+	Max    int
+	MaxBOF int
+	MaxEOF int
+
+	// ignoreEOF leaves the empty lines at the end of files to final-newline
+	// rule, instead of MaxEOF
+	ignoreEOF bool
+
+	ctx *Context
+}
+
+func NewNoMultipleEmptyLinesRule(ops map[string]interface{}) (Rule, error) {
+	rule := &NoMultipleEmptyLinesRule{}
+	ops = withDefaults(metadataNoMultipleEmptyLinesRule, ops)
+
+	for name, p := range map[string]*int{
+		"max":     &rule.Max,
+		"max-bof": &rule.MaxBOF,
+		"max-eof": &rule.MaxEOF,
+	} {
+		v, ok := ops[name]
+		if !ok {
+			continue
+		}
+		if value, ok := v.(int); ok && value >= 0 {
+			*p = value
+		} else {
+			return nil, fmt.Errorf("no-multiple-empty-lines.%s is only allow non-negative numbers: %v", name, v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *NoMultipleEmptyLinesRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewNoMultipleEmptyLinesRule(ops)
+}
+
+func (r *NoMultipleEmptyLinesRule) MetaData() *MetaData {
+	return metadataNoMultipleEmptyLinesRule
+}
+
+func (r *NoMultipleEmptyLinesRule) SetContext(ctx *Context) {
+	r.ctx = ctx
+}
+
+func (r *NoMultipleEmptyLinesRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+
+	linebreak := detectLinebreakStyle(s)
+
+	ls := bytes.Split(s, linebreak)
+	// the last element is not a line if the file ends with a line break
+	last := ls[len(ls)-1]
+	ls = ls[:len(ls)-1]
+	if len(last) > 0 {
+		ls = append(ls, last)
+	}
+
+	c := r.newCounter()
+	for _, l := range ls {
+		c.add(l)
+	}
+
+	removed := make(map[int]bool)
+	for _, run := range c.finish() {
+		res.AddReport(run.begin+1, 0, run.message())
+		for i := run.begin + run.max; i <= run.end; i++ {
+			removed[i] = true
+		}
+	}
+
+	fixed := make([][]byte, 0, len(ls)+1)
+	for i, l := range ls {
+		if !removed[i] {
+			fixed = append(fixed, l)
+		}
+	}
+	// keep the line break of the last line
+	if len(last) == 0 && len(fixed) > 0 {
+		fixed = append(fixed, last)
+	}
+	res.Set(bytes.Join(fixed, linebreak))
+
+	return res, nil
+}
+
+func (r *NoMultipleEmptyLinesRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+
+	c := r.newCounter()
+	if err := forEachLine(lr, func(l *Line) {
+		c.add(l.Text)
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, run := range c.finish() {
+		res.AddReport(run.begin+1, 0, run.message())
+	}
+
+	return res, nil
+}
+
+type emptyLineRun struct {
+	// begin and end are the indexes of the first and the last empty lines
+	begin int
+	end   int
+
+	// where is the position of the run in the file, "bof", "eof" or empty
+	where string
+	max   int
+}
+
+// emptyLineCounter counts the runs of empty lines line by line, and keeps
+// only the runs exceeding the limits.
+type emptyLineCounter struct {
+	rule *NoMultipleEmptyLinesRule
+
+	// markdown is the scanner of the regions if the file is Markdown, whose
+	// code blocks and front matter may have any empty lines
+	markdown *markdownScanner
+
+	num  int
+	run  *emptyLineRun
+	runs []*emptyLineRun
+}
+
+func (r *NoMultipleEmptyLinesRule) newCounter() *emptyLineCounter {
+	c := &emptyLineCounter{rule: r}
+	if r.ctx.IsMarkdown() {
+		c.markdown = &markdownScanner{}
+	}
+	return c
+}
+
+func (c *emptyLineCounter) add(l []byte) {
+	empty := isBlankLine(l)
+	if c.markdown != nil && c.markdown.scan(l) != TextRegion {
+		empty = false
+	}
+
+	i := c.num
+	c.num++
+	if !empty {
+		c.end(false)
+		return
+	}
+	if c.run == nil {
+		c.run = &emptyLineRun{begin: i}
+	}
+	c.run.end = i
+}
+
+// end ends the current run, which is at the end of the file if eof is true.
+func (c *emptyLineCounter) end(eof bool) {
+	run := c.run
+	if run == nil {
+		return
+	}
+	c.run = nil
+
+	// a file of only empty lines is counted at the beginning
+	run.max = c.rule.Max
+	switch {
+	case eof && c.rule.ignoreEOF:
+		return
+	case run.begin == 0:
+		run.where, run.max = "bof", c.rule.MaxBOF
+	case eof:
+		run.where, run.max = "eof", c.rule.MaxEOF
+	}
+
+	if run.end-run.begin+1 > run.max {
+		c.runs = append(c.runs, run)
+	}
+}
+
+// finish returns the runs of empty lines exceeding the limits.
+func (c *emptyLineCounter) finish() []*emptyLineRun {
+	c.end(true)
+	return c.runs
+}
+
+func (run *emptyLineRun) message() string {
+	lines := fmt.Sprintf("line %d", run.begin+1)
+	if run.end > run.begin {
+		lines = fmt.Sprintf("lines %d-%d", run.begin+1, run.end+1)
+	}
+
+	switch run.where {
+	case "bof":
+		return fmt.Sprintf("Too many empty lines at the beginning of the file (%s), at most %d allowed", lines, run.max)
+	case "eof":
+		return fmt.Sprintf("Too many empty lines at the end of the file (%s), at most %d allowed", lines, run.max)
+	}
+	return fmt.Sprintf("Too many consecutive empty lines (%s), at most %d allowed", lines, run.max)
+}
+
+func init() {
+	definedRules.Set(&NoMultipleEmptyLinesRule{})
+}
@@ -0,0 +1,109 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoMultipleEmptyLinesRule_Lint(t *testing.T) {
+	markdown := &Context{FileType: "markdown"}
+
+	tests := []struct {
+		rule    NoMultipleEmptyLinesRule
+		src     string
+		want    string
+		reports []string
+	}{
+		{
+			rule:    NoMultipleEmptyLinesRule{Max: 1},
+			src:     "a\n\nb\n",
+			want:    "a\n\nb\n",
+			reports: []string{},
+		},
+		{
+			rule: NoMultipleEmptyLinesRule{Max: 1},
+			src:  "a\n\n\n\n\n\nb\n\n \n\t\nc\n",
+			want: "a\n\nb\n\nc\n",
+			reports: []string{
+				"2:0: Too many consecutive empty lines (lines 2-6), at most 1 allowed",
+				"8:0: Too many consecutive empty lines (lines 8-10), at most 1 allowed",
+			},
+		},
+		{
+			rule:    NoMultipleEmptyLinesRule{Max: 0},
+			src:     "a\r\n\r\nb\r\n",
+			want:    "a\r\nb\r\n",
+			reports: []string{"2:0: Too many consecutive empty lines (line 2), at most 0 allowed"},
+		},
+		{
+			rule: NoMultipleEmptyLinesRule{Max: 2, MaxBOF: 1, MaxEOF: 1},
+			src:  "\r\n\r\na\r\n\r\n\r\n\r\nb\r\n\r\n\r\n",
+			want: "\r\na\r\n\r\n\r\nb\r\n\r\n",
+			reports: []string{
+				"1:0: Too many empty lines at the beginning of the file (lines 1-2), at most 1 allowed",
+				"4:0: Too many consecutive empty lines (lines 4-6), at most 2 allowed",
+				"8:0: Too many empty lines at the end of the file (lines 8-9), at most 1 allowed",
+			},
+		},
+		{
+			// the last line without a line break
+			rule:    NoMultipleEmptyLinesRule{Max: 1},
+			src:     "a\n\n\n ",
+			want:    "a",
+			reports: []string{"2:0: Too many empty lines at the end of the file (lines 2-4), at most 0 allowed"},
+		},
+		{
+			rule:    NoMultipleEmptyLinesRule{Max: 1},
+			src:     "\n\n",
+			want:    "",
+			reports: []string{"1:0: Too many empty lines at the beginning of the file (lines 1-2), at most 0 allowed"},
+		},
+		{
+			rule:    NoMultipleEmptyLinesRule{Max: 1},
+			src:     "",
+			want:    "",
+			reports: []string{},
+		},
+		{
+			// the empty lines in the front matter and the code blocks of
+			// Markdown are kept
+			rule:    NoMultipleEmptyLinesRule{Max: 1, ctx: markdown},
+			src:     "---\n\n\n---\na\n\n\n```\n\n\n```\nb\n",
+			want:    "---\n\n\n---\na\n\n```\n\n\n```\nb\n",
+			reports: []string{"6:0: Too many consecutive empty lines (lines 6-7), at most 1 allowed"},
+		},
+		{
+			rule: NoMultipleEmptyLinesRule{Max: 1},
+			src:  "a\n\n\n```\n\n\n```\nb\n",
+			want: "a\n\n```\n\n```\nb\n",
+			reports: []string{
+				"2:0: Too many consecutive empty lines (lines 2-3), at most 1 allowed",
+				"5:0: Too many consecutive empty lines (lines 5-6), at most 1 allowed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.want, string(got.Fixed), tt.src)
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports, tt.src)
+	}
+}
+
+func TestNewNoMultipleEmptyLinesRule(t *testing.T) {
+	rule, err := NewNoMultipleEmptyLinesRule(map[string]interface{}{"max-eof": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, &NoMultipleEmptyLinesRule{Max: 1, MaxBOF: 0, MaxEOF: 1}, rule)
+
+	_, err = NewNoMultipleEmptyLinesRule(map[string]interface{}{"max": -1})
+	assert.EqualError(t, err, "no-multiple-empty-lines.max is only allow non-negative numbers: -1")
+}
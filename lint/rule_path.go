@@ -0,0 +1,425 @@
+package lint
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var suggestRenameOption = &Option{
+	Name:        "suggest",
+	Type:        BoolOption,
+	Description: "suggest the new name with git mv command",
+	Default:     false,
+}
+
+var metadataFilenamePatternRule = &MetaData{
+	Name:        "filename-pattern",
+	Description: "This rule enforces the names of files to match the regular expression. The default pattern is kebab-case, optionally starting with a dot.",
+	Examples: []*Example{
+		{Filename: "docs/Getting Started.md"},
+		{Options: map[string]interface{}{"pattern": "^[a-z0-9_]+\\.go$", "suggest": true}, Filename: "lint/ruleNoBOM.go"},
+	},
+	Options: []*Option{
+		{
+			Name:        "pattern",
+			Type:        StringOption,
+			Description: "the regular expression of file names without directories",
+			Default:     `^\.?[a-z0-9]+(-[a-z0-9]+)*(\.[a-z0-9]+)*$`,
+		},
+		suggestRenameOption,
+	},
+}
+
+var metadataPathCharsRule = &MetaData{
+	Name:        "path-chars",
+	Description: "This rule disallows spaces and non-ASCII characters in paths, which need quoting in shells and are encoded differently by file systems.",
+	Examples: []*Example{
+		{Filename: "images/caf\u00E9.png"},
+		{Options: map[string]interface{}{"suggest": true}, Filename: "docs/release notes.md"},
+	},
+	Options: []*Option{
+		{
+			Name:        "allow-spaces",
+			Type:        BoolOption,
+			Description: "allow spaces in paths",
+			Default:     false,
+		},
+		{
+			Name:        "allow-non-ascii",
+			Type:        BoolOption,
+			Description: "allow non-ASCII characters in paths",
+			Default:     false,
+		},
+		suggestRenameOption,
+	},
+}
+
+var metadataPathMaxLengthRule = &MetaData{
+	Name:        "path-max-length",
+	Description: "This rule limits the length of paths in characters, which some file systems and tools can't handle.",
+	Examples: []*Example{
+		{Options: map[string]interface{}{"max": 20}, Filename: "src/components/navigation/NavigationBar.tsx"},
+	},
+	Options: []*Option{
+		{
+			Name:        "max",
+			Type:        IntOption,
+			Description: "the max length of paths",
+			Default:     255,
+			Min:         intRange(1),
+		},
+	},
+}
+
+var metadataPathCaseCollisionRule = &MetaData{
+	Name:        "path-case-collision",
+	Description: "This rule disallows paths which are the same as the other lint target files ignoring cases, such as README.md and readme.md, which can't be checked out together on case-insensitive file systems.",
+	Examples: []*Example{
+		{Filename: "README.md", Paths: []string{"readme.md"}},
+		{Filename: "Docs/intro.md", Paths: []string{"docs/usage.md"}},
+	},
+}
+
+// PathRule is implemented by the rules which lint the path of the file
+// instead of its content. Linter calls them even if the file is empty.
+type PathRule interface {
+	ContextRule
+
+	// LintPath lints the path of the file. paths is all the lint target files,
+	// or nil if it is unknown.
+	LintPath(file string, paths *Paths) (*Result, error)
+}
+
+// pathRule is embedded in the rules linting paths.
+type pathRule struct {
+	ctx *Context
+}
+
+func (r *pathRule) SetContext(ctx *Context) {
+	r.ctx = ctx
+}
+
+// lintPath lints the path of the file in the context of the rule, and
+// returns the result with s unchanged.
+func lintPath(pr PathRule, ctx *Context, s []byte) (*Result, error) {
+	if ctx == nil || ctx.Filename == "" {
+		res := NewResult()
+		res.Set(s)
+		return res, nil
+	}
+
+	res, err := pr.LintPath(ctx.Filename, ctx.Paths)
+	if err != nil {
+		return nil, err
+	}
+	res.Set(s)
+	return res, nil
+}
+
+type FilenamePatternRule struct {
+	pathRule
+
+	Pattern *regexp.Regexp
+	Suggest bool
+}
+
+func NewFilenamePatternRule(ops map[string]interface{}) (Rule, error) {
+	rule := &FilenamePatternRule{}
+	ops = withDefaults(metadataFilenamePatternRule, ops)
+
+	if v, ok := ops["pattern"]; ok {
+		s, _ := v.(string)
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return nil, fmt.Errorf("filename-pattern.pattern is invalid: %v", err)
+		}
+		rule.Pattern = re
+	}
+
+	if v, ok := ops["suggest"]; ok {
+		if value, ok := v.(bool); ok {
+			rule.Suggest = value
+		} else {
+			return nil, fmt.Errorf("filename-pattern.suggest is only allow boolean: %v", v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *FilenamePatternRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewFilenamePatternRule(ops)
+}
+
+func (r *FilenamePatternRule) MetaData() *MetaData {
+	return metadataFilenamePatternRule
+}
+
+func (r *FilenamePatternRule) Lint(s []byte) (*Result, error) {
+	return lintPath(r, r.ctx, s)
+}
+
+func (r *FilenamePatternRule) LintStream(lr *LineReader) (*Result, error) {
+	return lintPath(r, r.ctx, nil)
+}
+
+func (r *FilenamePatternRule) LintPath(file string, paths *Paths) (*Result, error) {
+	res := NewResult()
+
+	file = cleanPath(file)
+	dir, name := path.Split(file)
+	if r.Pattern.MatchString(name) {
+		return res, nil
+	}
+
+	msg := fmt.Sprintf("File name %q doesn't match %s", name, r.Pattern)
+	if r.Suggest {
+		for _, f := range []func(string) string{kebabCase, snakeCase, strings.ToLower} {
+			if s := f(name); s != name && r.Pattern.MatchString(s) {
+				msg += suggestRename(file, dir+s)
+				break
+			}
+		}
+	}
+	res.AddReport(0, 0, msg)
+
+	return res, nil
+}
+
+type PathCharsRule struct {
+	pathRule
+
+	AllowSpaces   bool
+	AllowNonASCII bool
+	Suggest       bool
+}
+
+func NewPathCharsRule(ops map[string]interface{}) (Rule, error) {
+	rule := &PathCharsRule{}
+	ops = withDefaults(metadataPathCharsRule, ops)
+
+	for name, p := range map[string]*bool{
+		"allow-spaces":    &rule.AllowSpaces,
+		"allow-non-ascii": &rule.AllowNonASCII,
+		"suggest":         &rule.Suggest,
+	} {
+		v, ok := ops[name]
+		if !ok {
+			continue
+		}
+		if value, ok := v.(bool); ok {
+			*p = value
+		} else {
+			return nil, fmt.Errorf("path-chars.%s is only allow boolean: %v", name, v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *PathCharsRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewPathCharsRule(ops)
+}
+
+func (r *PathCharsRule) MetaData() *MetaData {
+	return metadataPathCharsRule
+}
+
+func (r *PathCharsRule) Lint(s []byte) (*Result, error) {
+	return lintPath(r, r.ctx, s)
+}
+
+func (r *PathCharsRule) LintStream(lr *LineReader) (*Result, error) {
+	return lintPath(r, r.ctx, nil)
+}
+
+func (r *PathCharsRule) LintPath(file string, paths *Paths) (*Result, error) {
+	res := NewResult()
+
+	file = cleanPath(file)
+	hasSpaces := !r.AllowSpaces && strings.IndexFunc(file, unicode.IsSpace) >= 0
+
+	var nonASCII []string
+	if !r.AllowNonASCII {
+		for _, c := range file {
+			if c >= utf8.RuneSelf && !contains(nonASCII, string(c)) {
+				nonASCII = append(nonASCII, string(c))
+			}
+		}
+	}
+
+	// the rename can be suggested only if all the problems can be fixed
+	suggest := r.Suggest && len(nonASCII) == 0
+	if hasSpaces {
+		msg := "Path has spaces"
+		if suggest {
+			msg += suggestRename(file, strings.Join(strings.FieldsFunc(file, unicode.IsSpace), "-"))
+		}
+		res.AddReport(0, 0, msg)
+	}
+	if len(nonASCII) > 0 {
+		res.AddReport(0, 0, fmt.Sprintf("Path has non-ASCII characters %q", strings.Join(nonASCII, "")))
+	}
+
+	return res, nil
+}
+
+type PathMaxLengthRule struct {
+	pathRule
+
+	Max int
+}
+
+func NewPathMaxLengthRule(ops map[string]interface{}) (Rule, error) {
+	rule := &PathMaxLengthRule{}
+	ops = withDefaults(metadataPathMaxLengthRule, ops)
+
+	if v, ok := ops["max"]; ok {
+		if value, ok := v.(int); ok && value > 0 {
+			rule.Max = value
+		} else {
+			return nil, fmt.Errorf("path-max-length.max is only allow positive numbers: %v", v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *PathMaxLengthRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewPathMaxLengthRule(ops)
+}
+
+func (r *PathMaxLengthRule) MetaData() *MetaData {
+	return metadataPathMaxLengthRule
+}
+
+func (r *PathMaxLengthRule) Lint(s []byte) (*Result, error) {
+	return lintPath(r, r.ctx, s)
+}
+
+func (r *PathMaxLengthRule) LintStream(lr *LineReader) (*Result, error) {
+	return lintPath(r, r.ctx, nil)
+}
+
+func (r *PathMaxLengthRule) LintPath(file string, paths *Paths) (*Result, error) {
+	res := NewResult()
+
+	if n := utf8.RuneCountInString(cleanPath(file)); n > r.Max {
+		res.AddReport(0, 0, fmt.Sprintf("Path is %d characters long, at most %d allowed", n, r.Max))
+	}
+
+	return res, nil
+}
+
+type PathCaseCollisionRule struct {
+	pathRule
+}
+
+func NewPathCaseCollisionRule(ops map[string]interface{}) (Rule, error) {
+	return &PathCaseCollisionRule{}, nil
+}
+
+func (r *PathCaseCollisionRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewPathCaseCollisionRule(ops)
+}
+
+func (r *PathCaseCollisionRule) MetaData() *MetaData {
+	return metadataPathCaseCollisionRule
+}
+
+func (r *PathCaseCollisionRule) Lint(s []byte) (*Result, error) {
+	return lintPath(r, r.ctx, s)
+}
+
+func (r *PathCaseCollisionRule) LintStream(lr *LineReader) (*Result, error) {
+	return lintPath(r, r.ctx, nil)
+}
+
+func (r *PathCaseCollisionRule) LintPath(file string, paths *Paths) (*Result, error) {
+	res := NewResult()
+	if paths == nil {
+		return res, nil
+	}
+
+	part, others, err := paths.CaseCollision(file)
+	if err != nil {
+		return nil, err
+	}
+	if part == "" {
+		return res, nil
+	}
+
+	quoted := make([]string, 0, len(others))
+	for _, o := range others {
+		quoted = append(quoted, fmt.Sprintf("%q", o))
+	}
+	if part == cleanPath(file) {
+		res.AddReport(0, 0, fmt.Sprintf("Path collides with %s on case-insensitive file systems", strings.Join(quoted, ", ")))
+	} else {
+		res.AddReport(0, 0, fmt.Sprintf("Directory %q collides with %s on case-insensitive file systems", part, strings.Join(quoted, ", ")))
+	}
+
+	return res, nil
+}
+
+// suggestRename returns the suggestion to rename the file, which is appended
+// to the messages.
+func suggestRename(from, to string) string {
+	return fmt.Sprintf(", rename it: git mv %s %s", shellQuote(from), shellQuote(to))
+}
+
+var shellSafePattern = regexp.MustCompile(`^[A-Za-z0-9._/+-]+$`)
+
+// shellQuote quotes the string with single quotes if it has the characters
+// which need quoting in shells.
+func shellQuote(s string) string {
+	if shellSafePattern.MatchString(s) {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+func kebabCase(s string) string {
+	return joinWords(s, '-')
+}
+
+func snakeCase(s string) string {
+	return joinWords(s, '_')
+}
+
+// joinWords splits the name into lowercase words at spaces, hyphens,
+// underscores and the boundaries of camelCase, and joins them with sep. Dots
+// of the extensions are kept.
+func joinWords(s string, sep rune) string {
+	var ret []rune
+	var prev rune
+	pending := false
+	for _, c := range s {
+		switch {
+		case c == ' ' || c == '-' || c == '_':
+			pending = len(ret) > 0 && prev != '.'
+		case unicode.IsUpper(c) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			ret = append(ret, sep, unicode.ToLower(c))
+			pending = false
+		default:
+			if pending && c != '.' {
+				ret = append(ret, sep)
+			}
+			ret = append(ret, unicode.ToLower(c))
+			pending = false
+		}
+		prev = c
+	}
+	return string(ret)
+}
+
+func init() {
+	definedRules.Set(&FilenamePatternRule{})
+	definedRules.Set(&PathCharsRule{})
+	definedRules.Set(&PathMaxLengthRule{})
+	definedRules.Set(&PathCaseCollisionRule{})
+}
@@ -0,0 +1,140 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathRules_Lint(t *testing.T) {
+	newRule := func(f func(map[string]interface{}) (Rule, error), ops map[string]interface{}) Rule {
+		r, err := f(ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	paths := NewPaths([]string{"README.md", "readme.md", "docs/a.md"})
+
+	tests := []struct {
+		rule    Rule
+		file    string
+		reports []string
+	}{
+		{
+			rule:    newRule(NewFilenamePatternRule, nil),
+			file:    "docs/getting-started.v2.md",
+			reports: []string{},
+		},
+		{
+			rule:    newRule(NewFilenamePatternRule, nil),
+			file:    ".filelint.yml",
+			reports: []string{},
+		},
+		{
+			rule:    newRule(NewFilenamePatternRule, nil),
+			file:    "./docs/GettingStarted.md",
+			reports: []string{`0:0: File name "GettingStarted.md" doesn't match ^\.?[a-z0-9]+(-[a-z0-9]+)*(\.[a-z0-9]+)*$`},
+		},
+		{
+			rule:    newRule(NewFilenamePatternRule, map[string]interface{}{"suggest": true}),
+			file:    "docs/Getting_Started 2.md",
+			reports: []string{`0:0: File name "Getting_Started 2.md" doesn't match ^\.?[a-z0-9]+(-[a-z0-9]+)*(\.[a-z0-9]+)*$, rename it: git mv 'docs/Getting_Started 2.md' docs/getting-started-2.md`},
+		},
+		{
+			// no suggestion if the pattern can't be satisfied by renaming
+			rule:    newRule(NewFilenamePatternRule, map[string]interface{}{"pattern": `^[a-z]+\.txt$`, "suggest": true}),
+			file:    "Notes.md",
+			reports: []string{`0:0: File name "Notes.md" doesn't match ^[a-z]+\.txt$`},
+		},
+		{
+			rule: newRule(NewPathCharsRule, map[string]interface{}{"suggest": true}),
+			file: "my docs/caf\u00E9 \u00E0 la carte.md",
+			reports: []string{
+				"0:0: Path has spaces",
+				"0:0: Path has non-ASCII characters \"\u00E9\u00E0\"",
+			},
+		},
+		{
+			rule:    newRule(NewPathCharsRule, map[string]interface{}{"suggest": true, "allow-non-ascii": true}),
+			file:    "my  docs/caf\u00E9.md",
+			reports: []string{"0:0: Path has spaces, rename it: git mv 'my  docs/caf\u00E9.md' 'my-docs/caf\u00E9.md'"},
+		},
+		{
+			rule:    newRule(NewPathCharsRule, map[string]interface{}{"allow-spaces": true}),
+			file:    "my docs/a.md",
+			reports: []string{},
+		},
+		{
+			rule:    newRule(NewPathMaxLengthRule, map[string]interface{}{"max": 8}),
+			file:    "\u3042/12345.md",
+			reports: []string{"0:0: Path is 10 characters long, at most 8 allowed"},
+		},
+		{
+			rule:    newRule(NewPathMaxLengthRule, map[string]interface{}{"max": 10}),
+			file:    "./\u3042/12345.md",
+			reports: []string{},
+		},
+		{
+			rule:    newRule(NewPathCaseCollisionRule, nil),
+			file:    "README.md",
+			reports: []string{`0:0: Path collides with "readme.md" on case-insensitive file systems`},
+		},
+		{
+			rule:    newRule(NewPathCaseCollisionRule, nil),
+			file:    "Docs/b.md",
+			reports: []string{`0:0: Directory "Docs" collides with "docs" on case-insensitive file systems`},
+		},
+	}
+
+	for _, tt := range tests {
+		tt.rule.(ContextRule).SetContext(&Context{Filename: tt.file, Paths: paths})
+		got, err := tt.rule.Lint([]byte("a\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "a\n", string(got.Fixed))
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports, "%s: %s", tt.rule.MetaData().Name, tt.file)
+	}
+}
+
+func TestJoinWords(t *testing.T) {
+	tests := []struct {
+		src   string
+		kebab string
+		snake string
+	}{
+		{src: "GettingStarted.md", kebab: "getting-started.md", snake: "getting_started.md"},
+		{src: "my_file  name-2.TXT", kebab: "my-file-name-2.txt", snake: "my_file_name_2.txt"},
+		{src: "HTTPServer_v2.go", kebab: "httpserver-v2.go", snake: "httpserver_v2.go"},
+		{src: "a .b", kebab: "a.b", snake: "a.b"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.kebab, kebabCase(tt.src), tt.src)
+		assert.Equal(t, tt.snake, snakeCase(tt.src), tt.src)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, "docs/a-b_c.md", shellQuote("docs/a-b_c.md"))
+	assert.Equal(t, "'a b.md'", shellQuote("a b.md"))
+	assert.Equal(t, `'it'\''s.md'`, shellQuote("it's.md"))
+}
+
+func TestNewPathRules(t *testing.T) {
+	_, err := NewFilenamePatternRule(map[string]interface{}{"pattern": "("})
+	assert.EqualError(t, err, "filename-pattern.pattern is invalid: error parsing regexp: missing closing ): `(`")
+
+	_, err = NewPathCharsRule(map[string]interface{}{"allow-spaces": "yes"})
+	assert.EqualError(t, err, "path-chars.allow-spaces is only allow boolean: yes")
+
+	_, err = NewPathMaxLengthRule(map[string]interface{}{"max": 0})
+	assert.EqualError(t, err, "path-max-length.max is only allow positive numbers: 0")
+}
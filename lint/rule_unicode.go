@@ -0,0 +1,309 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var allowCodePointsOption = &Option{
+	Name:        "allow",
+	Type:        StringsOption,
+	Description: `the code points to allow such as "U+00A0", or the characters themselves`,
+	Default:     []string{},
+}
+
+var metadataUnicodeBidiRule = &MetaData{
+	Name:        "unicode-bidi",
+	Description: "This rule disallows bidirectional override and isolate characters (U+202A-U+202E, U+2066-U+2069), which make source code look different from how it is interpreted.",
+	Examples: []*Example{
+		{Source: "access := \"user\u202E \u2066// admin\u2069 \u2066\"\n"},
+	},
+	Options: []*Option{allowCodePointsOption},
+	rank:    1,
+}
+
+var metadataUnicodeZeroWidthRule = &MetaData{
+	Name:        "unicode-zero-width",
+	Description: "This rule disallows invisible zero-width characters such as U+200B ZERO WIDTH SPACE. A BOM at the beginning of files is the business of no-bom rule.",
+	Examples: []*Example{
+		{Source: "user\u200Bname\n"},
+	},
+	Options: []*Option{allowCodePointsOption},
+	rank:    1,
+}
+
+var metadataUnicodeNBSPRule = &MetaData{
+	Name:        "unicode-nbsp",
+	Description: "This rule disallows non-breaking spaces such as U+00A0, and fixes them into spaces.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Source: "a\u00A0= 1\n"},
+	},
+	Options: []*Option{allowCodePointsOption},
+
+	// this rule should be called before no-eol-space rule which removes the
+	// fixed spaces at the end of lines
+	rank: 1,
+}
+
+var metadataUnicodeFullwidthSpaceRule = &MetaData{
+	Name:        "unicode-fullwidth-space",
+	Description: "This rule disallows fullwidth spaces (U+3000), and fixes them into spaces.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Source: "a\u3000= 1\n"},
+	},
+	Options: []*Option{allowCodePointsOption},
+
+	// this rule should be called before no-eol-space rule which removes the
+	// fixed spaces at the end of lines
+	rank: 1,
+}
+
+// unicodeCharRule is the base of the rules which disallow some characters.
+type unicodeCharRule struct {
+	// Allow is the code points which are not reported
+	Allow map[rune]bool
+
+	// kind describes the characters in messages
+	kind string
+
+	// chars is the disallowed characters and their names
+	chars map[rune]string
+
+	// fix is the replacement of the characters, or empty if they can't be
+	// fixed safely
+	fix string
+}
+
+func newUnicodeCharRule(md *MetaData, ops map[string]interface{}) (unicodeCharRule, error) {
+	ops = withDefaults(md, ops)
+	rule := unicodeCharRule{}
+
+	if v, ok := ops["allow"]; ok {
+		allow, err := parseCodePoints(v)
+		if err != nil {
+			return rule, fmt.Errorf("%s.allow is invalid: %v", md.Name, err)
+		}
+		rule.Allow = allow
+	}
+
+	return rule, nil
+}
+
+type UnicodeBidiRule struct {
+	unicodeCharRule
+}
+
+func NewUnicodeBidiRule(ops map[string]interface{}) (Rule, error) {
+	base, err := newUnicodeCharRule(metadataUnicodeBidiRule, ops)
+	if err != nil {
+		return nil, err
+	}
+	base.kind = "Bidirectional control character"
+	base.chars = map[rune]string{
+		'\u202A': "LEFT-TO-RIGHT EMBEDDING",
+		'\u202B': "RIGHT-TO-LEFT EMBEDDING",
+		'\u202C': "POP DIRECTIONAL FORMATTING",
+		'\u202D': "LEFT-TO-RIGHT OVERRIDE",
+		'\u202E': "RIGHT-TO-LEFT OVERRIDE",
+		'\u2066': "LEFT-TO-RIGHT ISOLATE",
+		'\u2067': "RIGHT-TO-LEFT ISOLATE",
+		'\u2068': "FIRST STRONG ISOLATE",
+		'\u2069': "POP DIRECTIONAL ISOLATE",
+	}
+	return &UnicodeBidiRule{base}, nil
+}
+
+func (r *UnicodeBidiRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewUnicodeBidiRule(ops)
+}
+
+func (r *UnicodeBidiRule) MetaData() *MetaData {
+	return metadataUnicodeBidiRule
+}
+
+type UnicodeZeroWidthRule struct {
+	unicodeCharRule
+}
+
+func NewUnicodeZeroWidthRule(ops map[string]interface{}) (Rule, error) {
+	base, err := newUnicodeCharRule(metadataUnicodeZeroWidthRule, ops)
+	if err != nil {
+		return nil, err
+	}
+	base.kind = "Zero-width character"
+	base.chars = map[rune]string{
+		'\u200B': "ZERO WIDTH SPACE",
+		'\u200C': "ZERO WIDTH NON-JOINER",
+		'\u200D': "ZERO WIDTH JOINER",
+		'\u2060': "WORD JOINER",
+		'\uFEFF': "ZERO WIDTH NO-BREAK SPACE",
+	}
+	return &UnicodeZeroWidthRule{base}, nil
+}
+
+func (r *UnicodeZeroWidthRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewUnicodeZeroWidthRule(ops)
+}
+
+func (r *UnicodeZeroWidthRule) MetaData() *MetaData {
+	return metadataUnicodeZeroWidthRule
+}
+
+type UnicodeNBSPRule struct {
+	unicodeCharRule
+}
+
+func NewUnicodeNBSPRule(ops map[string]interface{}) (Rule, error) {
+	base, err := newUnicodeCharRule(metadataUnicodeNBSPRule, ops)
+	if err != nil {
+		return nil, err
+	}
+	base.kind = "Non-breaking space"
+	base.chars = map[rune]string{
+		'\u00A0': "NO-BREAK SPACE",
+		'\u2007': "FIGURE SPACE",
+		'\u202F': "NARROW NO-BREAK SPACE",
+	}
+	base.fix = " "
+	return &UnicodeNBSPRule{base}, nil
+}
+
+func (r *UnicodeNBSPRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewUnicodeNBSPRule(ops)
+}
+
+func (r *UnicodeNBSPRule) MetaData() *MetaData {
+	return metadataUnicodeNBSPRule
+}
+
+type UnicodeFullwidthSpaceRule struct {
+	unicodeCharRule
+}
+
+func NewUnicodeFullwidthSpaceRule(ops map[string]interface{}) (Rule, error) {
+	base, err := newUnicodeCharRule(metadataUnicodeFullwidthSpaceRule, ops)
+	if err != nil {
+		return nil, err
+	}
+	base.kind = "Fullwidth space"
+	base.chars = map[rune]string{
+		'\u3000': "IDEOGRAPHIC SPACE",
+	}
+	base.fix = " "
+	return &UnicodeFullwidthSpaceRule{base}, nil
+}
+
+func (r *UnicodeFullwidthSpaceRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewUnicodeFullwidthSpaceRule(ops)
+}
+
+func (r *UnicodeFullwidthSpaceRule) MetaData() *MetaData {
+	return metadataUnicodeFullwidthSpaceRule
+}
+
+func (r *unicodeCharRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+
+	ls := bytes.Split(s, UnixStyleLinebreak)
+	for i, l := range ls {
+		ls[i] = r.lintLine(res, i+1, l)
+	}
+	res.Set(bytes.Join(ls, UnixStyleLinebreak))
+
+	return res, nil
+}
+
+func (r *unicodeCharRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+
+	if err := forEachLine(lr, func(l *Line) {
+		r.lintLine(res, l.Num, l.Text)
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// lintLine reports the disallowed characters in the line with their columns
+// counted in characters, and returns the fixed line.
+func (r *unicodeCharRule) lintLine(res *Result, num int, l []byte) []byte {
+	var fixed []byte
+	col := 0
+	for i := 0; i < len(l); {
+		c, size := utf8.DecodeRune(l[i:])
+		col++
+
+		name, ok := r.chars[c]
+		// BOM is the business of no-bom rule
+		if !ok || r.Allow[c] || (num == 1 && i == 0 && c == '\uFEFF') {
+			if fixed != nil {
+				fixed = append(fixed, l[i:i+size]...)
+			}
+			i += size
+			continue
+		}
+
+		res.AddReport(num, col, fmt.Sprintf("%s %s (%s) is disallowed", r.kind, codePoint(c), name))
+		if r.fix != "" {
+			if fixed == nil {
+				fixed = append([]byte{}, l[:i]...)
+			}
+			fixed = append(fixed, r.fix...)
+		} else if fixed != nil {
+			fixed = append(fixed, l[i:i+size]...)
+		}
+		i += size
+	}
+
+	if fixed == nil {
+		return l
+	}
+	return fixed
+}
+
+// codePoint returns the notation of the code point like "U+00A0".
+func codePoint(c rune) string {
+	return fmt.Sprintf("U+%04X", c)
+}
+
+// parseCodePoints parses the code points like "U+00A0", or the characters.
+func parseCodePoints(v interface{}) (map[rune]bool, error) {
+	ss, err := toStrings(v)
+	if err != nil {
+		return nil, err
+	}
+
+	cps := make(map[rune]bool, len(ss))
+	for _, s := range ss {
+		if utf8.RuneCountInString(s) == 1 {
+			c, _ := utf8.DecodeRuneInString(s)
+			cps[c] = true
+			continue
+		}
+
+		hex := strings.TrimPrefix(strings.ToUpper(s), "U+")
+		if hex == strings.ToUpper(s) {
+			return nil, fmt.Errorf("%q is not a code point such as \"U+00A0\"", s)
+		}
+		n, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || n > utf8.MaxRune {
+			return nil, fmt.Errorf("%q is not a code point such as \"U+00A0\"", s)
+		}
+		cps[rune(n)] = true
+	}
+
+	return cps, nil
+}
+
+func init() {
+	definedRules.Set(&UnicodeBidiRule{})
+	definedRules.Set(&UnicodeZeroWidthRule{})
+	definedRules.Set(&UnicodeNBSPRule{})
+	definedRules.Set(&UnicodeFullwidthSpaceRule{})
+}
@@ -0,0 +1,185 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var metadataUnicodeMixedScriptRule = &MetaData{
+	Name:        "unicode-mixed-script",
+	Description: "This rule disallows words and identifiers mixing scripts such as Latin and Cyrillic, which can be confused with other identifiers like \"p\u0430ypal\" with Cyrillic \"\u0430\". The combinations of Han, Hiragana, Katakana, Bopomofo and Hangul with Latin are allowed.",
+	Examples: []*Example{
+		{Source: "if is\u0410dmin(user) {\n"},
+	},
+	Options: []*Option{allowCodePointsOption},
+	rank:    1,
+}
+
+// allowedScripts is the combinations of the scripts which are commonly used
+// together, defined by "Highly Restrictive" level of Unicode Technical
+// Standard #39.
+var allowedScripts = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+type UnicodeMixedScriptRule struct {
+	// Allow is the code points which are ignored
+	Allow map[rune]bool
+}
+
+func NewUnicodeMixedScriptRule(ops map[string]interface{}) (Rule, error) {
+	rule := &UnicodeMixedScriptRule{}
+	ops = withDefaults(metadataUnicodeMixedScriptRule, ops)
+
+	if v, ok := ops["allow"]; ok {
+		allow, err := parseCodePoints(v)
+		if err != nil {
+			return nil, fmt.Errorf("unicode-mixed-script.allow is invalid: %v", err)
+		}
+		rule.Allow = allow
+	}
+
+	return rule, nil
+}
+
+func (r *UnicodeMixedScriptRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewUnicodeMixedScriptRule(ops)
+}
+
+func (r *UnicodeMixedScriptRule) MetaData() *MetaData {
+	return metadataUnicodeMixedScriptRule
+}
+
+func (r *UnicodeMixedScriptRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+
+	for i, l := range bytes.Split(s, UnixStyleLinebreak) {
+		r.lintLine(res, i+1, l)
+	}
+	res.Set(s)
+
+	return res, nil
+}
+
+func (r *UnicodeMixedScriptRule) LintStream(lr *LineReader) (*Result, error) {
+	res := NewResult()
+
+	if err := forEachLine(lr, func(l *Line) {
+		r.lintLine(res, l.Num, l.Text)
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// lintLine reports the words mixing scripts in the line with their columns
+// counted in characters.
+func (r *UnicodeMixedScriptRule) lintLine(res *Result, num int, l []byte) {
+	// col is the number of the characters before i
+	col := 0
+	for i := 0; i < len(l); {
+		c, size := utf8.DecodeRune(l[i:])
+		if !isWordChar(c) {
+			i += size
+			col++
+			continue
+		}
+
+		begin, beginCol := i, col+1
+		ascii := true
+		for i < len(l) {
+			c, size = utf8.DecodeRune(l[i:])
+			if !isWordChar(c) {
+				break
+			}
+			if c >= utf8.RuneSelf {
+				ascii = false
+			}
+			i += size
+			col++
+		}
+
+		if ascii {
+			continue
+		}
+		if scripts := r.scripts(l[begin:i]); !isAllowedScripts(scripts) {
+			res.AddReport(num, beginCol, fmt.Sprintf("%q mixes %s scripts", l[begin:i], joinScripts(scripts)))
+		}
+	}
+}
+
+// scripts returns the sorted scripts of the letters in the word except the
+// common characters such as digits.
+func (r *UnicodeMixedScriptRule) scripts(word []byte) []string {
+	set := make(map[string]bool)
+	for _, c := range string(word) {
+		if r.Allow[c] || !unicode.IsLetter(c) {
+			continue
+		}
+		if c < utf8.RuneSelf {
+			set["Latin"] = true
+			continue
+		}
+		for name, table := range unicode.Scripts {
+			if name != "Common" && name != "Inherited" && unicode.Is(table, c) {
+				set[name] = true
+				break
+			}
+		}
+	}
+
+	scripts := make([]string, 0, len(set))
+	for name := range set {
+		scripts = append(scripts, name)
+	}
+	sort.Strings(scripts)
+	return scripts
+}
+
+func isAllowedScripts(scripts []string) bool {
+	if len(scripts) <= 1 {
+		return true
+	}
+	for _, allowed := range allowedScripts {
+		ok := true
+		for _, s := range scripts {
+			if !contains(allowed, s) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func joinScripts(scripts []string) string {
+	n := len(scripts)
+	return strings.Join(scripts[:n-1], ", ") + " and " + scripts[n-1]
+}
+
+func isWordChar(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.Is(unicode.Mn, c)
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	definedRules.Set(&UnicodeMixedScriptRule{})
+}
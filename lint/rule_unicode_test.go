@@ -0,0 +1,111 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnicodeRules_Lint(t *testing.T) {
+	newRule := func(f func(map[string]interface{}) (Rule, error), ops map[string]interface{}) Rule {
+		r, err := f(ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	tests := []struct {
+		rule    Rule
+		src     string
+		want    string
+		reports []string
+	}{
+		{
+			rule: newRule(NewUnicodeBidiRule, nil),
+			src:  "a\n\"\u00E9\u202E\" // \u2066x\u2069\n",
+			want: "a\n\"\u00E9\u202E\" // \u2066x\u2069\n",
+			reports: []string{
+				"2:3: Bidirectional control character U+202E (RIGHT-TO-LEFT OVERRIDE) is disallowed",
+				"2:9: Bidirectional control character U+2066 (LEFT-TO-RIGHT ISOLATE) is disallowed",
+				"2:11: Bidirectional control character U+2069 (POP DIRECTIONAL ISOLATE) is disallowed",
+			},
+		},
+		{
+			rule:    newRule(NewUnicodeBidiRule, map[string]interface{}{"allow": []interface{}{"U+2066", "\u2069"}}),
+			src:     "\u2066x\u2069\u202A",
+			want:    "\u2066x\u2069\u202A",
+			reports: []string{"1:4: Bidirectional control character U+202A (LEFT-TO-RIGHT EMBEDDING) is disallowed"},
+		},
+		{
+			// BOM at the beginning is not a zero-width character
+			rule: newRule(NewUnicodeZeroWidthRule, nil),
+			src:  "\uFEFFa\u200Bb\r\n\uFEFF",
+			want: "\uFEFFa\u200Bb\r\n\uFEFF",
+			reports: []string{
+				"1:3: Zero-width character U+200B (ZERO WIDTH SPACE) is disallowed",
+				"2:1: Zero-width character U+FEFF (ZERO WIDTH NO-BREAK SPACE) is disallowed",
+			},
+		},
+		{
+			rule: newRule(NewUnicodeNBSPRule, nil),
+			src:  "a\u00A0=\u202F1\r\n\xff\u00A0",
+			want: "a = 1\r\n\xff ",
+			reports: []string{
+				"1:2: Non-breaking space U+00A0 (NO-BREAK SPACE) is disallowed",
+				"1:4: Non-breaking space U+202F (NARROW NO-BREAK SPACE) is disallowed",
+				"2:2: Non-breaking space U+00A0 (NO-BREAK SPACE) is disallowed",
+			},
+		},
+		{
+			rule:    newRule(NewUnicodeFullwidthSpaceRule, nil),
+			src:     "\u3042\u3000\u3044\n",
+			want:    "\u3042 \u3044\n",
+			reports: []string{"1:2: Fullwidth space U+3000 (IDEOGRAPHIC SPACE) is disallowed"},
+		},
+		{
+			rule: newRule(NewUnicodeMixedScriptRule, nil),
+			src:  "p\u0430ypal := \u043C\u0438\u0440\n\u65E5\u672C\u8A9E\u306Etext \u03B1_beta2 caf\u00E9",
+			want: "p\u0430ypal := \u043C\u0438\u0440\n\u65E5\u672C\u8A9E\u306Etext \u03B1_beta2 caf\u00E9",
+			reports: []string{
+				"1:1: \"p\u0430ypal\" mixes Cyrillic and Latin scripts",
+				"2:10: \"\u03B1_beta2\" mixes Greek and Latin scripts",
+			},
+		},
+		{
+			rule:    newRule(NewUnicodeMixedScriptRule, map[string]interface{}{"allow": []string{"U+03B1"}}),
+			src:     "\u03B1_beta",
+			want:    "\u03B1_beta",
+			reports: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, tt.want, string(got.Fixed), "%s: %q", tt.rule.MetaData().Name, tt.src)
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports, "%s: %q", tt.rule.MetaData().Name, tt.src)
+	}
+}
+
+func TestParseCodePoints(t *testing.T) {
+	got, err := parseCodePoints([]interface{}{"U+00A0", "u+3000", "\u200B"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[rune]bool{'\u00A0': true, '\u3000': true, '\u200B': true}, got)
+
+	_, err = parseCodePoints([]interface{}{"00A0"})
+	assert.EqualError(t, err, `"00A0" is not a code point such as "U+00A0"`)
+
+	_, err = parseCodePoints([]interface{}{"U+FFFFFFFF"})
+	assert.Error(t, err)
+
+	_, err = NewUnicodeNBSPRule(map[string]interface{}{"allow": []interface{}{1}})
+	assert.EqualError(t, err, "unicode-nbsp.allow is invalid: not a string: 1")
+}
@@ -0,0 +1,167 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// the options shared by the rules validating structured files
+
+var allowDuplicateKeysOption = &Option{
+	Name:        "allow-duplicate-keys",
+	Type:        BoolOption,
+	Description: "allow the same keys in a mapping, which most parsers silently overwrite with the last one or reject",
+	Default:     false,
+}
+
+var sortKeysOption = &Option{
+	Name:        "sort-keys",
+	Type:        BoolOption,
+	Description: "enforce the keys of mappings to be sorted in ascending order",
+	Default:     false,
+}
+
+var prettyPrintOption = &Option{
+	Name:        "pretty-print",
+	Type:        BoolOption,
+	Description: "enforce files to be pretty-printed with indent, keeping the empty lines at the beginning and the end of files",
+	Default:     false,
+}
+
+// mappingKey is a key of a JSON object or a YAML mapping with its position.
+type mappingKey struct {
+	name string
+	line int
+	col  int
+}
+
+// keyChecker reports the duplicate keys and the unsorted keys of mappings.
+type keyChecker struct {
+	AllowDuplicateKeys bool
+	SortKeys           bool
+}
+
+func newKeyChecker(name string, ops map[string]interface{}) (*keyChecker, error) {
+	kc := &keyChecker{}
+	for opt, p := range map[string]*bool{
+		"allow-duplicate-keys": &kc.AllowDuplicateKeys,
+		"sort-keys":            &kc.SortKeys,
+	} {
+		v, ok := ops[opt]
+		if !ok {
+			continue
+		}
+		if value, ok := v.(bool); ok {
+			*p = value
+		} else {
+			return nil, fmt.Errorf("%s.%s is only allow boolean: %v", name, opt, v)
+		}
+	}
+	return kc, nil
+}
+
+// check reports the problems of the keys of a mapping.
+func (kc *keyChecker) check(res *Result, keys []*mappingKey) {
+	seen := make(map[string]*mappingKey, len(keys))
+	var prev *mappingKey
+	for _, k := range keys {
+		if first, ok := seen[k.name]; ok {
+			if !kc.AllowDuplicateKeys {
+				res.AddUnfixableReport(k.line, k.col, fmt.Sprintf("Key %q is already defined at line %d", k.name, first.line))
+			}
+			continue
+		}
+		seen[k.name] = k
+
+		if kc.SortKeys && prev != nil && k.name < prev.name {
+			res.AddUnfixableReport(k.line, k.col, fmt.Sprintf("Key %q should be before %q", k.name, prev.name))
+		}
+		prev = k
+	}
+}
+
+// sourcePosition returns the line and the column in characters of the
+// offset in s.
+func sourcePosition(s []byte, offset int) (line, col int) {
+	if offset > len(s) {
+		offset = len(s)
+	}
+	begin := bytes.LastIndexByte(s[:offset], '\n') + 1
+	return bytes.Count(s[:offset], []byte("\n")) + 1, utf8.RuneCount(s[begin:offset]) + 1
+}
+
+// lineOffset returns the offset of the line number n in s.
+func lineOffset(s []byte, n int) int {
+	offset := 0
+	for ; n > 1; n-- {
+		i := bytes.IndexByte(s[offset:], '\n')
+		if i < 0 {
+			return len(s)
+		}
+		offset += i + 1
+	}
+	return offset
+}
+
+// sortReports sorts the reports by their positions, since the problems of
+// nested mappings are found before their parents.
+func sortReports(res *Result) {
+	sort.SliceStable(res.Reports, func(i, j int) bool {
+		a, b := res.Reports[i].position, res.Reports[j].position
+		if a.column != b.column {
+			return a.column < b.column
+		}
+		return a.row < b.row
+	})
+}
+
+// prettyPrint returns s formatted by format keeping the BOM, the linebreak
+// style and the empty lines at the beginning and the end of s, which are left
+// to no-bom, linebreak, first-newline and final-newline rules. format is
+// called with the body without them, and returns the lines separated by LF.
+func prettyPrint(s []byte, format func(body []byte) ([]byte, error)) ([]byte, error) {
+	linebreak := detectLinebreakStyle(s)
+	body := bytes.TrimPrefix(s, UTF8BOMs)
+	offset := len(s) - len(body)
+
+	// the indent of the first line is kept
+	space := body[:len(body)-len(bytes.TrimLeft(body, " \t\r\n"))]
+	begin := bytes.LastIndexByte(space, '\n') + 1
+	leading := bytes.Count(body[:begin], []byte("\n"))
+	end := len(bytes.TrimRight(body, " \t\r\n"))
+	if end < begin {
+		end = begin
+	}
+	trailing := bytes.Count(body[end:], []byte("\n"))
+
+	out, err := format(body[begin:end])
+	if err != nil {
+		return nil, err
+	}
+	out = bytes.TrimRight(out, "\n")
+	if !bytes.Equal(linebreak, []byte("\n")) {
+		out = bytes.Replace(out, []byte("\n"), linebreak, -1)
+	}
+
+	fixed := make([]byte, 0, len(s)+len(out))
+	fixed = append(fixed, s[:offset]...)
+	fixed = append(fixed, bytes.Repeat(linebreak, leading)...)
+	fixed = append(fixed, out...)
+	fixed = append(fixed, bytes.Repeat(linebreak, trailing)...)
+	return fixed, nil
+}
+
+// firstDifferentLine returns the first line number where a and b differ, or
+// 0 if they are the same.
+func firstDifferentLine(a, b []byte) int {
+	if bytes.Equal(a, b) {
+		return 0
+	}
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return bytes.Count(a[:n], []byte("\n")) + 1
+}
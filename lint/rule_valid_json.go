@@ -0,0 +1,190 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var metadataValidJSONRule = &MetaData{
+	Name:        "valid-json",
+	Description: "This rule reports the syntax errors of JSON files with their positions. Optionally it reports the duplicate keys and the unsorted keys of objects, and pretty-prints files.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Options: map[string]interface{}{"pretty-print": true}, Source: "{\"name\": \"filelint\", \"keywords\": [\"lint\"]}\n"},
+		{Options: map[string]interface{}{"pretty-print": true, "sort-keys": true}, Source: "{\n  \"b\": 1,\n  \"a\": 2,\n    \"b\": 3\n}\n"},
+	},
+	Options: []*Option{
+		allowDuplicateKeysOption,
+		sortKeysOption,
+		prettyPrintOption,
+		{
+			Name:        "indent",
+			Type:        IntOption,
+			Description: "the number of spaces to indent with pretty-print",
+			Default:     2,
+			Min:         intRange(1),
+		},
+	},
+
+	// this rule should be called after linebreak rule, and before
+	// final-newline rule which fixes the newlines kept by pretty-print
+	rank: 1,
+}
+
+type ValidJSONRule struct {
+	*keyChecker
+	PrettyPrint bool
+	Indent      int
+}
+
+func NewValidJSONRule(ops map[string]interface{}) (Rule, error) {
+	rule := &ValidJSONRule{}
+	ops = withDefaults(metadataValidJSONRule, ops)
+
+	kc, err := newKeyChecker("valid-json", ops)
+	if err != nil {
+		return nil, err
+	}
+	rule.keyChecker = kc
+
+	if v, ok := ops["pretty-print"]; ok {
+		if value, ok := v.(bool); ok {
+			rule.PrettyPrint = value
+		} else {
+			return nil, fmt.Errorf("valid-json.pretty-print is only allow boolean: %v", v)
+		}
+	}
+
+	if v, ok := ops["indent"]; ok {
+		if value, ok := v.(int); ok && value > 0 {
+			rule.Indent = value
+		} else {
+			return nil, fmt.Errorf("valid-json.indent is only allow positive numbers: %v", v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *ValidJSONRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewValidJSONRule(ops)
+}
+
+func (r *ValidJSONRule) MetaData() *MetaData {
+	return metadataValidJSONRule
+}
+
+func (r *ValidJSONRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+	res.Set(s)
+
+	// BOM is reported by no-bom rule
+	body := bytes.TrimPrefix(s, UTF8BOMs)
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		serr, ok := err.(*json.SyntaxError)
+		if !ok {
+			return nil, err
+		}
+		// Offset is just after the invalid character
+		pos := int(serr.Offset)
+		if pos > 0 && pos < len(body) {
+			pos--
+		}
+		line, col := sourcePosition(body, pos)
+		res.AddUnfixableReport(line, col, fmt.Sprintf("JSON syntax error: %v", serr))
+		return res, nil
+	}
+
+	r.checkObjects(res, body)
+	sortReports(res)
+
+	if r.PrettyPrint {
+		fixed, err := r.prettyPrint(s)
+		if err != nil {
+			return nil, err
+		}
+		if line := firstDifferentLine(s, fixed); line > 0 {
+			res.AddReport(line, 0, fmt.Sprintf("JSON is not pretty-printed with %d spaces indent", r.Indent))
+			res.Set(fixed)
+		}
+	}
+
+	return res, nil
+}
+
+// checkObjects checks the keys of the objects in the valid JSON.
+func (r *ValidJSONRule) checkObjects(res *Result, s []byte) {
+	type frame struct {
+		object    bool
+		expectKey bool
+		keys      []*mappingKey
+	}
+	var stack []*frame
+
+	dec := json.NewDecoder(bytes.NewReader(s))
+	dec.UseNumber()
+	for {
+		begin := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err != nil {
+			// io.EOF, or the syntax error already reported
+			return
+		}
+
+		var top *frame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			stack = append(stack, &frame{object: tok == json.Delim('{'), expectKey: tok == json.Delim('{')})
+			continue
+		case json.Delim('}'), json.Delim(']'):
+			if top.object {
+				r.check(res, top.keys)
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 {
+				stack[len(stack)-1].expectKey = true
+			}
+			continue
+		}
+
+		if top == nil || !top.object {
+			continue
+		}
+		if !top.expectKey {
+			top.expectKey = true
+			continue
+		}
+
+		// the key follows the previous token, and a comma
+		for begin < len(s) && bytes.IndexByte([]byte(" \t\r\n,"), s[begin]) >= 0 {
+			begin++
+		}
+		line, col := sourcePosition(s, begin)
+		top.keys = append(top.keys, &mappingKey{name: tok.(string), line: line, col: col})
+		top.expectKey = false
+	}
+}
+
+// prettyPrint returns the pretty-printed JSON keeping the BOM, the linebreak
+// style and the empty lines at the beginning and the end of s.
+func (r *ValidJSONRule) prettyPrint(s []byte) ([]byte, error) {
+	return prettyPrint(s, func(body []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, body, "", strings.Repeat(" ", r.Indent)); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	})
+}
+
+func init() {
+	definedRules.Set(&ValidJSONRule{})
+}
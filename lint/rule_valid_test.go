@@ -0,0 +1,344 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidJSONRule_Lint(t *testing.T) {
+	tests := []struct {
+		ops     map[string]interface{}
+		src     string
+		want    string
+		reports []string
+	}{
+		{
+			src:     "{\"a\": [1, 2], \"b\": {\"c\": null}}\n",
+			reports: []string{},
+		},
+		{
+			src:     "{\n  \"a\": 1,\n  \"b\": 2,\n}\n",
+			reports: []string{"4:1: JSON syntax error: invalid character '}' looking for beginning of object key string"},
+		},
+		{
+			src:     "\ufeff{\"\u3042\": tru}\n",
+			reports: []string{"1:10: JSON syntax error: invalid character '}' in literal true (expecting 'e')"},
+		},
+		{
+			src:     "{\"a\": [1, 2\n",
+			reports: []string{"2:1: JSON syntax error: unexpected end of JSON input"},
+		},
+		{
+			src:     "",
+			reports: []string{"1:1: JSON syntax error: unexpected end of JSON input"},
+		},
+		{
+			src: "{\"a\": 1, \"b\": {\"x\": 1, \"x\": 2}, \"a\": 3}\n",
+			reports: []string{
+				`1:24: Key "x" is already defined at line 1`,
+				`1:33: Key "a" is already defined at line 1`,
+			},
+		},
+		{
+			ops:     map[string]interface{}{"allow-duplicate-keys": true},
+			src:     "{\"a\": 1, \"a\": 2}\n",
+			reports: []string{},
+		},
+		{
+			ops: map[string]interface{}{"sort-keys": true},
+			src: "[{\"b\": 1, \"a\": [{\"d\": 1, \"c\": 2}]},\n {\"a\": 1, \"b\": 2}]\n",
+			reports: []string{
+				`1:11: Key "a" should be before "b"`,
+				`1:26: Key "c" should be before "d"`,
+			},
+		},
+		{
+			ops:     map[string]interface{}{"pretty-print": true},
+			src:     "\n{\"a\": [1, {}], \"b\": \"<&>\"}\n\n",
+			want:    "\n{\n  \"a\": [\n    1,\n    {}\n  ],\n  \"b\": \"<&>\"\n}\n\n",
+			reports: []string{"2:0: JSON is not pretty-printed with 2 spaces indent"},
+		},
+		{
+			ops:     map[string]interface{}{"pretty-print": true, "indent": 4},
+			src:     "{\r\n    \"a\": 1,\r\n  \"b\": 2\r\n}",
+			want:    "{\r\n    \"a\": 1,\r\n    \"b\": 2\r\n}",
+			reports: []string{"3:0: JSON is not pretty-printed with 4 spaces indent"},
+		},
+		{
+			ops:     map[string]interface{}{"pretty-print": true},
+			src:     "{\n  \"a\": 1\n}\n",
+			reports: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		rule, err := NewValidJSONRule(tt.ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := tt.want
+		if want == "" {
+			want = tt.src
+		}
+		assert.Equal(t, want, string(got.Fixed), "%q", tt.src)
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports, "%q", tt.src)
+	}
+}
+
+func TestValidYAMLRule_Lint(t *testing.T) {
+	tests := []struct {
+		ops     map[string]interface{}
+		src     string
+		want    string
+		reports []string
+	}{
+		{
+			src:     "a: 1\nb:\n  - c: [1, 2]\n    d: |\n      e: 1\n      e: 2\n",
+			reports: []string{},
+		},
+		{
+			src:     "a: 1\n  b: 2\n",
+			reports: []string{"2:4: YAML syntax error: mapping values are not allowed in this context"},
+		},
+		{
+			// the flow sequence is continued to the next line
+			src:     "a: [1, 2\nb: 3\n",
+			reports: []string{"1:8: YAML syntax error: did not find expected ',' or ']'"},
+		},
+		{
+			src:     "a: 'b\n",
+			reports: []string{"2:1: YAML syntax error: found unexpected end of stream"},
+		},
+		{
+			src:     "\ufeff: a\n",
+			reports: []string{"1:1: YAML syntax error: did not find expected key"},
+		},
+		{
+			// the documents after the first one are also parsed
+			src:     "%YAML 1.1\n---\na: 1\n---\nb: 2\n...\nc: 3\n  d: 4\n",
+			reports: []string{"8:4: YAML syntax error: mapping values are not allowed in this context"},
+		},
+		{
+			src: "a: 1\nb:\n  c: 1\n  \"c\": 2\n- x\n",
+			reports: []string{
+				"5:1: YAML syntax error: did not find expected key",
+			},
+		},
+		{
+			src: "a: 1\nb:\n  c: 1\n  \"c\": 2\nitems:\n- x: 1\n  x: 2\n- x: 3\nflow: {y: 1, \u3042: {z: 1, z: 2}, y: 3}\na: 4\n---\na: 1\n",
+			reports: []string{
+				`4:3: Key "c" is already defined at line 3`,
+				`7:3: Key "x" is already defined at line 6`,
+				`9:24: Key "z" is already defined at line 9`,
+				`9:31: Key "y" is already defined at line 9`,
+				`10:1: Key "a" is already defined at line 1`,
+			},
+		},
+		{
+			ops: map[string]interface{}{"sort-keys": true, "allow-duplicate-keys": true},
+			src: "b: 1\na:\n  - d: 1\n    c: 2\n    c: 3\n  - [{f: 1, e: 2}]\n",
+			reports: []string{
+				`2:1: Key "a" should be before "b"`,
+				`4:5: Key "c" should be before "d"`,
+				`6:13: Key "e" should be before "f"`,
+			},
+		},
+		{
+			ops:     map[string]interface{}{"pretty-print": true},
+			src:     "\n# head\na:\n    b: [1, 2]   # line\n    c:\n    - d: 'e'\n\n\n",
+			want:    "\n# head\na:\n  b: [1, 2] # line\n  c:\n    - d: 'e'\n\n\n",
+			reports: []string{"4:0: YAML is not pretty-printed with 2 spaces indent"},
+		},
+		{
+			ops:     map[string]interface{}{"pretty-print": true, "indent": 4},
+			src:     "---\r\na:\r\n  b: 1\r\n---\r\nc: 2\r\n",
+			want:    "---\r\na:\r\n    b: 1\r\n---\r\nc: 2\r\n",
+			reports: []string{"3:0: YAML is not pretty-printed with 4 spaces indent"},
+		},
+		{
+			ops:     map[string]interface{}{"pretty-print": true},
+			src:     "a:\n  b: 1\n",
+			reports: []string{},
+		},
+		{
+			// the duplicate keys are not pretty-printed not to lose them
+			ops:     map[string]interface{}{"pretty-print": true},
+			src:     "a:\n    b: 1\n    b: 2\n",
+			reports: []string{`3:5: Key "b" is already defined at line 2`},
+		},
+	}
+
+	for _, tt := range tests {
+		rule, err := NewValidYAMLRule(tt.ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := tt.want
+		if want == "" {
+			want = tt.src
+		}
+		assert.Equal(t, want, string(got.Fixed), "%q", tt.src)
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports, "%q", tt.src)
+	}
+}
+
+func TestValidTOMLRule_Lint(t *testing.T) {
+	type test struct {
+		ops     map[string]interface{}
+		src     string
+		want    string
+		reports []string
+	}
+	tests := []test{
+		{
+			src:     "[a]\nb = 1\n",
+			reports: []string{},
+		},
+		{
+			src:     "\ufeffa = \"\u3042\\q\"\n",
+			reports: []string{`1:8: TOML syntax error: invalid escaped character U+0071 'q'`},
+		},
+		{
+			src:     "a = [1 2]\n",
+			reports: []string{`1:8: TOML syntax error: array elements must be separated by commas`},
+		},
+		{
+			src:     "a = 1\r\n[b]\r\nc = 2\r\nc = 3\r\n",
+			reports: []string{`4:1: Key "c" is already defined at line 3`},
+		},
+		{
+			src: "[a]\n[b]\n[a]\nx = {y = 1, \"y\" = 2}\n",
+			reports: []string{
+				`3:2: Key "a" is already defined at line 1`,
+				`4:13: Key "y" is already defined at line 4`,
+			},
+		},
+		{
+			ops:     map[string]interface{}{"allow-duplicate-keys": true},
+			src:     "a = 1\na = 2\n",
+			reports: []string{},
+		},
+		{
+			// the key redefined as a table is found by decoding
+			src:     "a = 1\n[a.b]\n",
+			reports: []string{`2:2: TOML syntax error: expected a to be a table, not a value`},
+		},
+		{
+			src:     "a = 1\nb = 2019-02-29\n",
+			reports: []string{`2:5: TOML syntax error: impossible date`},
+		},
+		{
+			ops: map[string]interface{}{"sort-keys": true},
+			src: "b = 1\na.d = {y = 1, x = 2}\na.c = 3\n[[t]]\nb = 1\n[[t]]\nb = 1\na = 2\n[s]\n",
+			reports: []string{
+				`2:1: Key "a" should be before "b"`,
+				`2:15: Key "x" should be before "y"`,
+				`3:3: Key "c" should be before "d"`,
+				`8:1: Key "a" should be before "b"`,
+				`9:2: Key "s" should be before "t"`,
+			},
+		},
+		{
+			ops:     map[string]interface{}{"pretty-print": true},
+			src:     "\n# head\na . b= 1\n\n[ server ]   # c\n  port = [\n  1,\n]\n  host = \"a # b\"\n[[ x . \"y z\" ]]\n\n\n",
+			want:    "\n# head\na.b = 1\n\n[server] # c\nport = [\n  1,\n]\nhost = \"a # b\"\n[[x.\"y z\"]]\n\n\n",
+			reports: []string{"3:0: TOML is not pretty-printed"},
+		},
+		{
+			// the comments just before the table headers are not indented
+			ops:     map[string]interface{}{"pretty-print": true, "indent": 2},
+			src:     "a = 1\r\n# b\r\n[b]\r\nc = '''\r\nd'''\r\n# e\r\n\r\n# f\r\n# g\r\n[f]\r\n",
+			want:    "a = 1\r\n# b\r\n[b]\r\n  c = '''\r\nd'''\r\n  # e\r\n\r\n# f\r\n# g\r\n[f]\r\n",
+			reports: []string{"4:0: TOML is not pretty-printed"},
+		},
+		{
+			ops:     map[string]interface{}{"pretty-print": true},
+			src:     "a = 1 # b\n[c]\nd = 2\n",
+			reports: []string{},
+		},
+		{
+			// the duplicate keys are not pretty-printed
+			ops:     map[string]interface{}{"pretty-print": true},
+			src:     "a=1\na=2\n",
+			reports: []string{`2:1: Key "a" is already defined at line 1`},
+		},
+	}
+
+	// the valid documents don't have the duplicate keys
+	for _, src := range []string{
+		"",
+		"# comment\n\n",
+		"a = \"\"\"\nline \\\n   continued\"\"\"\nb = '''\n'quoted''''\n",
+		"dt = [1979-05-27T07:32:00Z, 1979-05-27 07:32:00.999-08:00, 2000-02-29, 23:59:59.5]\n",
+		"point = {x = 1, y = {z = 2}, \"a b\".c = 3}\nempty = {}\nps = [{x = 1}, {x = 2}]\n",
+		"[a.b.c]\nd = 1\n[a]\ne = 1\n[ a . \"b\" . 'f' ]\n",
+		"[[fruits]]\nname = 'apple'\n[fruits.physical]\ncolor = 'red'\n[[fruits.varieties]]\nname = 'red'\n[[fruits]]\nname = 'banana'\n[fruits.physical]\n",
+		"[fruit]\napple.color = 'red'\napple.taste.sweet = true\n[fruit.apple.texture]\nsmooth = true\n",
+	} {
+		tests = append(tests, test{src: src, reports: []string{}})
+	}
+
+	for _, tt := range tests {
+		rule, err := NewValidTOMLRule(tt.ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := rule.Lint([]byte(tt.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := tt.want
+		if want == "" {
+			want = tt.src
+		}
+		assert.Equal(t, want, string(got.Fixed), "%q", tt.src)
+
+		reports := []string{}
+		for _, r := range got.Reports {
+			reports = append(reports, r.String())
+		}
+		assert.Equal(t, tt.reports, reports, "%q", tt.src)
+	}
+}
+
+func TestNewValidRules(t *testing.T) {
+	_, err := NewValidJSONRule(map[string]interface{}{"indent": 0})
+	assert.EqualError(t, err, "valid-json.indent is only allow positive numbers: 0")
+
+	_, err = NewValidJSONRule(map[string]interface{}{"pretty-print": "yes"})
+	assert.EqualError(t, err, "valid-json.pretty-print is only allow boolean: yes")
+
+	_, err = NewValidYAMLRule(map[string]interface{}{"indent": 1})
+	assert.EqualError(t, err, "valid-yaml.indent is only allow numbers from 2 to 9: 1")
+
+	_, err = NewValidYAMLRule(map[string]interface{}{"sort-keys": 1})
+	assert.EqualError(t, err, "valid-yaml.sort-keys is only allow boolean: 1")
+
+	_, err = NewValidTOMLRule(map[string]interface{}{"indent": -1})
+	assert.EqualError(t, err, "valid-toml.indent is only allow non-negative numbers: -1")
+
+	_, err = NewValidTOMLRule(map[string]interface{}{"allow-duplicate-keys": "yes"})
+	assert.EqualError(t, err, "valid-toml.allow-duplicate-keys is only allow boolean: yes")
+}
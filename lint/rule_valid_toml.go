@@ -0,0 +1,470 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+var metadataValidTOMLRule = &MetaData{
+	Name:        "valid-toml",
+	Description: "This rule reports the syntax errors of TOML files with their positions, including the keys and the tables defined twice. Optionally it reports the unsorted keys of tables, and pretty-prints files keeping the comments and the values.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Options: map[string]interface{}{"pretty-print": true}, Source: "[ package ]\nname=\"filelint\"\n  version = \"1.0.0\"   # comment\n"},
+		{Options: map[string]interface{}{"pretty-print": true, "sort-keys": true, "indent": 2}, Source: "[server]\nport = 8080\nhost = \"localhost\"\n"},
+	},
+	Options: []*Option{
+		allowDuplicateKeysOption,
+		sortKeysOption,
+		prettyPrintOption,
+		{
+			Name:        "indent",
+			Type:        IntOption,
+			Description: "the number of spaces to indent the key/value pairs and the comments in tables with pretty-print",
+			Default:     0,
+			Min:         intRange(0),
+		},
+	},
+
+	rank: 1,
+}
+
+type ValidTOMLRule struct {
+	*keyChecker
+	PrettyPrint bool
+	Indent      int
+}
+
+func NewValidTOMLRule(ops map[string]interface{}) (Rule, error) {
+	rule := &ValidTOMLRule{}
+	ops = withDefaults(metadataValidTOMLRule, ops)
+
+	kc, err := newKeyChecker("valid-toml", ops)
+	if err != nil {
+		return nil, err
+	}
+	rule.keyChecker = kc
+
+	if v, ok := ops["pretty-print"]; ok {
+		if value, ok := v.(bool); ok {
+			rule.PrettyPrint = value
+		} else {
+			return nil, fmt.Errorf("valid-toml.pretty-print is only allow boolean: %v", v)
+		}
+	}
+
+	if v, ok := ops["indent"]; ok {
+		if value, ok := v.(int); ok && value >= 0 {
+			rule.Indent = value
+		} else {
+			return nil, fmt.Errorf("valid-toml.indent is only allow non-negative numbers: %v", v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *ValidTOMLRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewValidTOMLRule(ops)
+}
+
+func (r *ValidTOMLRule) MetaData() *MetaData {
+	return metadataValidTOMLRule
+}
+
+func (r *ValidTOMLRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+	res.Set(s)
+
+	// BOM is reported by no-bom rule
+	body := bytes.TrimPrefix(s, UTF8BOMs)
+
+	keys, exprs, err := tomlMappingKeys(body)
+	if err != nil {
+		perr, ok := err.(*unstable.ParserError)
+		if !ok {
+			return nil, err
+		}
+		line, col := sourcePosition(body, tomlErrorOffset(body, perr))
+		res.AddUnfixableReport(line, col, fmt.Sprintf("TOML syntax error: %s", perr.Message))
+		return res, nil
+	}
+
+	duplicated := false
+	for _, ks := range keys {
+		r.check(res, ks)
+		duplicated = duplicated || hasDuplicateKeys(ks)
+	}
+	sortReports(res)
+
+	// the other errors such as a key redefined as a table are found by
+	// decoding, which fails with the duplicate keys reported above
+	if duplicated {
+		return res, nil
+	}
+	if offset, err := tomlDecode(body, exprs); err != nil {
+		line, col := sourcePosition(body, offset)
+		res.AddUnfixableReport(line, col, fmt.Sprintf("TOML syntax error: %s", strings.TrimPrefix(err.Error(), "toml: ")))
+		return res, nil
+	}
+
+	if r.PrettyPrint {
+		fixed, err := r.prettyPrint(s)
+		if err != nil {
+			return nil, err
+		}
+		if line := firstDifferentLine(s, fixed); line > 0 {
+			res.AddReport(line, 0, "TOML is not pretty-printed")
+			res.Set(fixed)
+		}
+	}
+
+	return res, nil
+}
+
+// tomlExpression is an expression of a TOML document written in the
+// canonical form.
+type tomlExpression struct {
+	kind unstable.Kind
+	text string
+
+	// begin is the offset of the line of the expression, and end is the
+	// offset of the end of the expression. value is the offset of the value
+	// of the key/value pair without comment, which ends at the next line.
+	begin, end, value int
+
+	indent bool
+}
+
+// prettyPrint returns the TOML with the key/value pairs, the table headers and
+// the comments written in the canonical form, keeping the values, the empty
+// lines between them, the BOM, the linebreak style and the empty lines at the
+// beginning and the end of s.
+func (r *ValidTOMLRule) prettyPrint(s []byte) ([]byte, error) {
+	return prettyPrint(s, func(body []byte) ([]byte, error) {
+		exprs, err := tomlExpressions(body)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		indent := strings.Repeat(" ", r.Indent)
+		for i, e := range exprs {
+			if i > 0 {
+				gap := body[exprs[i-1].end:e.begin]
+				buf.Write(bytes.Repeat([]byte("\n"), bytes.Count(gap, []byte("\n"))))
+			}
+			if e.indent {
+				buf.WriteString(indent)
+			}
+			buf.WriteString(e.text)
+		}
+		return buf.Bytes(), nil
+	})
+}
+
+// tomlExpressions returns the expressions of the TOML document s including
+// the comments.
+func tomlExpressions(s []byte) ([]*tomlExpression, error) {
+	var p unstable.Parser
+	p.KeepComments = true
+	p.Reset(s)
+
+	// the blanks in the values of multiple lines are kept, but not the
+	// linebreaks which are converted by the caller
+	text := func(b []byte) string {
+		return string(bytes.Replace(b, []byte("\r\n"), []byte("\n"), -1))
+	}
+
+	var exprs []*tomlExpression
+	table := false
+	for p.NextExpression() {
+		expr := p.Expression()
+		e := &tomlExpression{kind: expr.Kind, value: -1}
+
+		var comment *unstable.Node
+		switch expr.Kind {
+		case unstable.Comment:
+			comment = expr
+			e.begin = int(expr.Raw.Offset)
+			e.indent = table
+		case unstable.Table, unstable.ArrayTable:
+			key, end := tomlKeyText(s, expr)
+			e.begin = end
+			brackets := 1
+			if expr.Kind == unstable.ArrayTable {
+				brackets = 2
+			}
+			e.text = strings.Repeat("[", brackets) + key + strings.Repeat("]", brackets)
+			e.end = len(s) - len(bytes.TrimLeft(s[end:], " \t")) + brackets
+			comment = expr.Next()
+			table = true
+		case unstable.KeyValue:
+			key, end := tomlKeyText(s, expr)
+			e.begin = end
+			e.text = key + " = "
+			rest := bytes.TrimLeft(s[end:], " \t")
+			rest = bytes.TrimLeft(rest[1:], " \t")
+			e.value = len(s) - len(rest)
+			e.indent = table
+			comment = expr.Next()
+		default:
+			continue
+		}
+		e.begin = bytes.LastIndexByte(s[:e.begin], '\n') + 1
+
+		if comment != nil {
+			begin := int(comment.Raw.Offset)
+			if e.value >= 0 {
+				e.text += text(bytes.TrimRight(s[e.value:begin], " \t"))
+				e.value = -1
+			}
+			if e.text != "" {
+				e.text += " "
+			}
+			e.text += string(bytes.TrimRight(comment.Data, " \t\r"))
+			e.end = begin + int(comment.Raw.Length)
+		}
+		exprs = append(exprs, e)
+	}
+	if err := p.Error(); err != nil {
+		return nil, err
+	}
+
+	for i, e := range exprs {
+		if e.value < 0 {
+			continue
+		}
+		end := len(s)
+		if i+1 < len(exprs) {
+			end = exprs[i+1].begin
+		}
+		value := bytes.TrimRight(s[e.value:end], " \t\r\n")
+		e.text += text(value)
+		e.end = e.value + len(value)
+	}
+
+	// the comments just before the table headers are not indented
+	for i := len(exprs) - 1; i > 0; i-- {
+		e, prev := exprs[i], exprs[i-1]
+		header := e.kind == unstable.Table || e.kind == unstable.ArrayTable || (e.kind == unstable.Comment && !e.indent)
+		if header && prev.kind == unstable.Comment && bytes.Count(s[prev.end:e.begin], []byte("\n")) == 1 {
+			prev.indent = false
+		}
+	}
+
+	return exprs, nil
+}
+
+// tomlKeyText returns the key of the expression with the dots without spaces,
+// and the offset of the end of the key.
+func tomlKeyText(s []byte, expr *unstable.Node) (string, int) {
+	var keys []string
+	end := 0
+	it := expr.Key()
+	for it.Next() {
+		raw := it.Node().Raw
+		keys = append(keys, string(s[raw.Offset:raw.Offset+raw.Length]))
+		end = int(raw.Offset + raw.Length)
+	}
+	return strings.Join(keys, "."), end
+}
+
+// tomlDecode decodes the TOML document s, and returns the error with its
+// offset. exprs is the offsets of the expressions in s.
+func tomlDecode(s []byte, exprs []int) (int, error) {
+	decode := func(s []byte) error {
+		var v map[string]interface{}
+		return toml.Unmarshal(s, &v)
+	}
+
+	err := decode(s)
+	if err == nil {
+		return 0, nil
+	}
+	if derr, ok := err.(*toml.DecodeError); ok {
+		row, col := derr.Position()
+		return lineOffset(s, row) + col - 1, err
+	}
+
+	// the errors without positions are found by decoding the expressions
+	// from the beginning, which fails once the invalid one is included
+	end := func(i int) int {
+		if i+1 < len(exprs) {
+			return bytes.LastIndexByte(s[:exprs[i+1]], '\n') + 1
+		}
+		return len(s)
+	}
+	i := sort.Search(len(exprs), func(i int) bool {
+		return decode(s[:end(i)]) != nil
+	})
+	if i == len(exprs) {
+		return 0, err
+	}
+	return exprs[i], err
+}
+
+// tomlErrorOffset returns the offset of the error in s, which is parsed by
+// the parser of the error.
+func tomlErrorOffset(s []byte, err *unstable.ParserError) int {
+	if len(err.Highlight) == 0 {
+		return len(s)
+	}
+	var p unstable.Parser
+	p.Reset(s)
+	return int(p.Range(err.Highlight).Offset)
+}
+
+func hasDuplicateKeys(keys []*mappingKey) bool {
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if seen[k.name] {
+			return true
+		}
+		seen[k.name] = true
+	}
+	return false
+}
+
+// tomlKeys collects the keys of the tables in a TOML document.
+type tomlKeys struct {
+	s []byte
+
+	// mappings is the keys of the tables by their paths in the order of
+	// appearance. The key/value pairs and the sub-tables of a table are
+	// separate mappings, since key/value pairs must come first.
+	mappings map[string][]*mappingKey
+	paths    []string
+
+	// defined tells whether the key of the mapping is defined explicitly,
+	// rather than implicitly by dotted keys or sub-tables
+	defined map[string]map[string]bool
+
+	// arrays is the number of the elements of arrays of tables
+	arrays map[string]int
+}
+
+// tomlMappingKeys returns the keys of each table of the TOML document s,
+// which may be defined twice, and the offsets of the expressions. It returns
+// the syntax error of s.
+func tomlMappingKeys(s []byte) ([][]*mappingKey, []int, error) {
+	tk := &tomlKeys{
+		s:        s,
+		mappings: make(map[string][]*mappingKey),
+		defined:  make(map[string]map[string]bool),
+		arrays:   make(map[string]int),
+	}
+
+	var p unstable.Parser
+	p.Reset(s)
+
+	var exprs []int
+	table := ""
+	for p.NextExpression() {
+		expr := p.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable, unstable.KeyValue:
+			it := expr.Key()
+			it.Next()
+			exprs = append(exprs, int(it.Node().Raw.Offset))
+		}
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			table = tk.table(expr)
+		case unstable.KeyValue:
+			tk.keyValue(table, expr)
+		}
+	}
+	if err := p.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([][]*mappingKey, 0, len(tk.paths))
+	for _, path := range tk.paths {
+		keys = append(keys, tk.mappings[path])
+	}
+	return keys, exprs, nil
+}
+
+// table adds the keys of the table header, and returns the path of the table.
+func (tk *tomlKeys) table(expr *unstable.Node) string {
+	path := ""
+	it := expr.Key()
+	for it.Next() {
+		n := it.Node()
+		last := it.IsLast()
+
+		// the header of an array of tables is repeated for each element
+		tk.add(path+"\x01", n, last && expr.Kind == unstable.Table)
+		path += "\x00" + string(n.Data)
+		if last && expr.Kind == unstable.ArrayTable {
+			tk.arrays[path]++
+		}
+		if i, ok := tk.arrays[path]; ok {
+			path += "#" + strconv.Itoa(i)
+		}
+	}
+	return path
+}
+
+// keyValue adds the key of the key/value pair in the table, and the keys of
+// its inline tables.
+func (tk *tomlKeys) keyValue(table string, expr *unstable.Node) {
+	path := table
+	it := expr.Key()
+	for it.Next() {
+		n := it.Node()
+		tk.add(path, n, it.IsLast())
+		path += "\x00" + string(n.Data)
+	}
+	tk.value(path, expr.Value())
+}
+
+func (tk *tomlKeys) value(path string, v *unstable.Node) {
+	switch v.Kind {
+	case unstable.InlineTable:
+		it := v.Children()
+		for it.Next() {
+			tk.keyValue(path, it.Node())
+		}
+	case unstable.Array:
+		i := 0
+		it := v.Children()
+		for it.Next() {
+			tk.value(path+"["+strconv.Itoa(i)+"]", it.Node())
+			i++
+		}
+	}
+}
+
+// add adds the key to the mapping. The implicitly defined keys are added
+// only once, and the key defined twice explicitly is added again.
+func (tk *tomlKeys) add(path string, n *unstable.Node, explicit bool) {
+	name := string(n.Data)
+
+	defined, ok := tk.defined[path]
+	if !ok {
+		defined = make(map[string]bool)
+		tk.defined[path] = defined
+		tk.paths = append(tk.paths, path)
+	}
+	seen, found := defined[name]
+	if found && (!explicit || !seen) {
+		defined[name] = seen || explicit
+		return
+	}
+	defined[name] = explicit
+
+	line, col := sourcePosition(tk.s, int(n.Raw.Offset))
+	tk.mappings[path] = append(tk.mappings[path], &mappingKey{name: name, line: line, col: col})
+}
+
+func init() {
+	definedRules.Set(&ValidTOMLRule{})
+}
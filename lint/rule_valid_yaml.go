@@ -0,0 +1,462 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+var metadataValidYAMLRule = &MetaData{
+	Name:        "valid-yaml",
+	Description: "This rule reports the syntax errors of YAML files with their positions. Optionally it reports the duplicate keys and the unsorted keys of block mappings and flow mappings written in a line, and pretty-prints files keeping the comments.",
+	Fixable:     true,
+	Examples: []*Example{
+		{Options: map[string]interface{}{"pretty-print": true}, Source: "name: filelint\nkeywords:\n    - lint  # comment\n"},
+		{Options: map[string]interface{}{"pretty-print": true, "sort-keys": true}, Source: "b: 1\na:\n    d: 2\n    c: 3\n"},
+	},
+	Options: []*Option{
+		allowDuplicateKeysOption,
+		sortKeysOption,
+		prettyPrintOption,
+		{
+			Name:        "indent",
+			Type:        IntOption,
+			Description: "the number of spaces to indent with pretty-print",
+			Default:     2,
+			Min:         intRange(2),
+			Max:         intRange(9),
+		},
+	},
+
+	rank: 1,
+}
+
+type ValidYAMLRule struct {
+	*keyChecker
+	PrettyPrint bool
+	Indent      int
+}
+
+func NewValidYAMLRule(ops map[string]interface{}) (Rule, error) {
+	rule := &ValidYAMLRule{}
+	ops = withDefaults(metadataValidYAMLRule, ops)
+
+	kc, err := newKeyChecker("valid-yaml", ops)
+	if err != nil {
+		return nil, err
+	}
+	rule.keyChecker = kc
+
+	if v, ok := ops["pretty-print"]; ok {
+		if value, ok := v.(bool); ok {
+			rule.PrettyPrint = value
+		} else {
+			return nil, fmt.Errorf("valid-yaml.pretty-print is only allow boolean: %v", v)
+		}
+	}
+
+	if v, ok := ops["indent"]; ok {
+		if value, ok := v.(int); ok && value >= 2 && value <= 9 {
+			rule.Indent = value
+		} else {
+			return nil, fmt.Errorf("valid-yaml.indent is only allow numbers from 2 to 9: %v", v)
+		}
+	}
+
+	return rule, nil
+}
+
+func (r *ValidYAMLRule) New(ops map[string]interface{}) (Rule, error) {
+	return NewValidYAMLRule(ops)
+}
+
+func (r *ValidYAMLRule) MetaData() *MetaData {
+	return metadataValidYAMLRule
+}
+
+// yamlErrorPattern matches the error messages of the YAML parser, which have
+// the line numbers from 1 but not the columns.
+var yamlErrorPattern = regexp.MustCompile(`(?s)^yaml: (?:line (\d+): )?(.*)$`)
+
+func (r *ValidYAMLRule) Lint(s []byte) (*Result, error) {
+	res := NewResult()
+	res.Set(s)
+
+	// BOM is reported by no-bom rule
+	body := bytes.TrimPrefix(s, UTF8BOMs)
+
+	// the parser reads only the first document
+	for _, doc := range splitYAMLDocuments(body) {
+		err := parseYAML(doc.src)
+		if err == nil {
+			continue
+		}
+
+		offset, msg := yamlErrorOffset(doc.src, err)
+		line, col := sourcePosition(doc.src, offset)
+		res.AddUnfixableReport(doc.line+line, col, fmt.Sprintf("YAML syntax error: %s", msg))
+		return res, nil
+	}
+
+	duplicated := false
+	for _, keys := range yamlMappingKeys(body) {
+		r.check(res, keys)
+		duplicated = duplicated || hasDuplicateKeys(keys)
+	}
+	sortReports(res)
+
+	// the duplicate keys would be lost by pretty-print
+	if r.PrettyPrint && !duplicated {
+		fixed, err := r.prettyPrint(s)
+		if err != nil {
+			return nil, err
+		}
+		if line := firstDifferentLine(s, fixed); line > 0 {
+			res.AddReport(line, 0, fmt.Sprintf("YAML is not pretty-printed with %d spaces indent", r.Indent))
+			res.Set(fixed)
+		}
+	}
+
+	return res, nil
+}
+
+// parseYAML parses the YAML document s without decoding it, so that the
+// duplicate keys are left to keyChecker.
+func parseYAML(s []byte) error {
+	var n yaml.Node
+	return yaml.Unmarshal(s, &n)
+}
+
+// yamlErrorOffset returns the offset of the syntax error in the YAML document
+// s, and the message of the error without the line number.
+func yamlErrorOffset(s []byte, err error) (int, string) {
+	m := yamlErrorPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0, err.Error()
+	}
+	begin := 0
+	if m[1] != "" {
+		n, _ := strconv.Atoi(m[1])
+		begin = lineOffset(s, n)
+	}
+
+	// the invalid character is found by parsing the prefixes of s from the
+	// reported line, which fail with the same error once it is included
+	n := sort.Search(len(s)-begin, func(i int) bool {
+		perr := parseYAML(s[:begin+i+1])
+		return perr != nil && perr.Error() == err.Error()
+	})
+	return begin + n, m[2]
+}
+
+// prettyPrint returns the pretty-printed YAML keeping the comments, the BOM,
+// the linebreak style and the empty lines at the beginning and the end of s.
+func (r *ValidYAMLRule) prettyPrint(s []byte) ([]byte, error) {
+	return prettyPrint(s, func(body []byte) ([]byte, error) {
+		var buf bytes.Buffer
+
+		// the encoder writes the markers only between the documents
+		first := body
+		if i := bytes.IndexByte(body, '\n'); i >= 0 {
+			first = body[:i]
+		}
+		if isYAMLDocumentStart(strings.TrimRight(string(first), " \t\r")) {
+			buf.WriteString("---\n")
+		}
+
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(r.Indent)
+		dec := yaml.NewDecoder(bytes.NewReader(body))
+		for {
+			var n yaml.Node
+			err := dec.Decode(&n)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if err := enc.Encode(&n); err != nil {
+				return nil, err
+			}
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	})
+}
+
+type yamlDocument struct {
+	// line is the line number of the beginning of the document from 0
+	line int
+	src  []byte
+}
+
+// splitYAMLDocuments splits s at the document markers "---" and "...".
+func splitYAMLDocuments(s []byte) []*yamlDocument {
+	var docs []*yamlDocument
+	ls := bytes.SplitAfter(s, []byte("\n"))
+
+	begin, offset := 0, 0
+	cut := func(end int) {
+		n := 0
+		for _, l := range ls[begin:end] {
+			n += len(l)
+		}
+		docs = append(docs, &yamlDocument{line: begin, src: s[offset : offset+n]})
+		begin, offset = end, offset+n
+	}
+
+	content := false
+	for i, l := range ls {
+		text := strings.TrimRight(string(l), " \t\r\n")
+		if isYAMLDocumentStart(text) && content {
+			cut(i)
+			content = false
+		}
+
+		if text == "..." {
+			cut(i + 1)
+			content = false
+			continue
+		}
+
+		// directives and comments belong to the next document
+		if text != "" && text[0] != '#' && text[0] != '%' {
+			content = true
+		}
+	}
+	cut(len(ls))
+
+	return docs
+}
+
+func isYAMLDocumentStart(text string) bool {
+	return text == "---" || strings.HasPrefix(text, "--- ")
+}
+
+type yamlFrame struct {
+	indent int
+
+	// keys is the keys of the mapping in this node
+	keys []*mappingKey
+
+	// isKey is true if the frame is a mapping key whose value follows
+	isKey bool
+
+	// isBlock is true if the frame is a block scalar like `|` or `>`
+	isBlock bool
+}
+
+// yamlMappingKeys returns the keys of each mapping in the valid YAML source.
+// It supports block mappings and sequences, and flow collections written in
+// a line.
+func yamlMappingKeys(s []byte) [][]*mappingKey {
+	var mappings [][]*mappingKey
+	flush := func(f *yamlFrame) {
+		if len(f.keys) > 0 {
+			mappings = append(mappings, f.keys)
+		}
+	}
+
+	stack := []*yamlFrame{{indent: -1}}
+	for n, line := range strings.Split(string(s), "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		column := func(offset int) int {
+			return utf8.RuneCountInString(line[:offset]) + 1
+		}
+
+		if top := stack[len(stack)-1]; top.isBlock {
+			if indent > top.indent || strings.TrimSpace(line) == "" {
+				continue
+			}
+		}
+
+		if isYAMLDocumentStart(trimmed) || trimmed == "..." {
+			for i := len(stack) - 1; i >= 0; i-- {
+				flush(stack[i])
+			}
+			stack = []*yamlFrame{{indent: -1}}
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "%") {
+			continue
+		}
+
+		isItem := trimmed == "-" || strings.HasPrefix(trimmed, "- ")
+
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+			if top.indent < indent {
+				break
+			}
+			// a sequence can be written at the same indent as the key
+			if top.indent == indent && top.isKey && isItem {
+				break
+			}
+			flush(top)
+			stack = stack[:len(stack)-1]
+		}
+
+		parent := stack[len(stack)-1]
+		col := indent
+
+		for isItem {
+			item := &yamlFrame{indent: col}
+			stack = append(stack, item)
+			parent = item
+
+			rest := strings.TrimLeft(trimmed[1:], " ")
+			col += len(trimmed) - len(rest)
+			trimmed = rest
+			isItem = trimmed == "-" || strings.HasPrefix(trimmed, "- ")
+		}
+
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, ">") {
+			stack = append(stack, &yamlFrame{indent: col, isBlock: true})
+			continue
+		}
+
+		key, value, ok := splitYAMLKeyValue(trimmed)
+		if !ok {
+			if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+				mappings = append(mappings, yamlFlowKeys(trimmed, n+1, column(col)-1)...)
+			}
+			continue
+		}
+		parent.keys = append(parent.keys, &mappingKey{name: key, line: n + 1, col: column(col)})
+
+		valueOffset := col + len(trimmed) - len(value)
+		switch {
+		case value == "" || strings.HasPrefix(value, "#"):
+			stack = append(stack, &yamlFrame{indent: col, isKey: true})
+		case strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">"):
+			stack = append(stack, &yamlFrame{indent: col, isBlock: true})
+		case strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{"):
+			mappings = append(mappings, yamlFlowKeys(value, n+1, column(valueOffset)-1)...)
+		}
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		flush(stack[i])
+	}
+	return mappings
+}
+
+// splitYAMLKeyValue splits `key: value` into the key and the value.
+func splitYAMLKeyValue(s string) (key, value string, ok bool) {
+	if s[0] == '"' || s[0] == '\'' {
+		end := strings.IndexByte(s[1:], s[0])
+		if end < 0 {
+			return "", "", false
+		}
+		key = s[1 : end+1]
+		s = strings.TrimLeft(s[end+2:], " ")
+		if !strings.HasPrefix(s, ":") {
+			return "", "", false
+		}
+		return key, strings.TrimLeft(s[1:], " "), true
+	}
+
+	if s[0] == '[' || s[0] == '{' {
+		return "", "", false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ') {
+			return strings.TrimSpace(s[:i]), strings.TrimLeft(s[i+1:], " "), true
+		}
+		if s[i] == ' ' && i+1 < len(s) && s[i+1] == '#' {
+			break
+		}
+	}
+
+	return "", "", false
+}
+
+// yamlFlowKeys returns the keys of each mapping in the flow collection like
+// `[a, b]` or `{a: 1, b: 2}` following col characters of the line.
+func yamlFlowKeys(s string, line, col int) [][]*mappingKey {
+	var mappings [][]*mappingKey
+	var keys []*mappingKey
+
+	open := s[0]
+	close := byte(']')
+	if open == '{' {
+		close = '}'
+	}
+
+	depth := 0
+	var quote byte
+	start := 1
+
+	item := func(end int) {
+		raw := s[start:end]
+		text := strings.TrimLeft(raw, " ")
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		itemCol := col + utf8.RuneCountInString(s[:start+len(raw)-len(text)])
+
+		if open == '[' {
+			if text[0] == '[' || text[0] == '{' {
+				mappings = append(mappings, yamlFlowKeys(text, line, itemCol)...)
+			}
+			return
+		}
+
+		key, value, ok := splitYAMLKeyValue(text)
+		if !ok {
+			return
+		}
+		keys = append(keys, &mappingKey{name: key, line: line, col: itemCol + 1})
+		if value != "" && (value[0] == '[' || value[0] == '{') {
+			mappings = append(mappings, yamlFlowKeys(value, line, itemCol+utf8.RuneCountInString(text[:len(text)-len(value)]))...)
+		}
+	}
+
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case (c == ']' || c == '}') && depth > 0:
+			depth--
+		case c == ',' && depth == 0:
+			item(i)
+			start = i + 1
+		case c == close && depth == 0:
+			item(i)
+			if len(keys) > 0 {
+				mappings = append(mappings, keys)
+			}
+			return mappings
+		}
+	}
+
+	return mappings
+}
+
+func init() {
+	definedRules.Set(&ValidYAMLRule{})
+}
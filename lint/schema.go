@@ -0,0 +1,117 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+)
+
+type OptionType string
+
+const (
+	BoolOption    OptionType = "boolean"
+	IntOption     OptionType = "integer"
+	StringOption  OptionType = "string"
+	StringsOption OptionType = "array"
+)
+
+// Option is the schema of a rule option.
+type Option struct {
+	Name        string
+	Type        OptionType
+	Description string
+	Default     interface{}
+
+	// Enum is the available values of the string option, or the items of the
+	// strings option
+	Enum []string
+
+	// Min and Max are the range of the integer option
+	Min *int
+	Max *int
+}
+
+// EnforceOption is the option which every rule has.
+var EnforceOption = &Option{
+	Name:        "enforce",
+	Type:        BoolOption,
+	Description: "enable the rule",
+	Default:     false,
+}
+
+// SeverityOption is the option which every rule has.
+var SeverityOption = &Option{
+	Name:        "severity",
+	Type:        StringOption,
+	Description: "the severity of the reports, warnings are printed but don't fail the lint",
+	Default:     string(SeverityError),
+	Enum:        []string{string(SeverityError), string(SeverityWarning)},
+}
+
+func intRange(n int) *int {
+	return &n
+}
+
+// Option returns the schema of the option, or nil if the rule doesn't have it.
+func (md *MetaData) Option(name string) *Option {
+	switch name {
+	case EnforceOption.Name:
+		return EnforceOption
+	case SeverityOption.Name:
+		return SeverityOption
+	}
+	for _, o := range md.Options {
+		if o.Name == name {
+			return o
+		}
+	}
+	return nil
+}
+
+// AllOptions returns the options of the rule including the common options.
+func (md *MetaData) AllOptions() []*Option {
+	return append([]*Option{EnforceOption, SeverityOption}, md.Options...)
+}
+
+// OptionNames returns the sorted names of all options of the rule.
+func (md *MetaData) OptionNames() []string {
+	var names []string
+	for _, o := range md.AllOptions() {
+		names = append(names, o.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// withDefaults returns the options filled with the default values.
+func withDefaults(md *MetaData, ops map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{}, len(ops)+len(md.Options))
+	for _, o := range md.Options {
+		if o.Default != nil {
+			ret[o.Name] = o.Default
+		}
+	}
+	for k, v := range ops {
+		ret[k] = v
+	}
+	return ret
+}
+
+// toStrings returns the value of a StringsOption, which is []interface{} when
+// it is read from config files.
+func toStrings(v interface{}) ([]string, error) {
+	switch vs := v.(type) {
+	case []string:
+		return vs, nil
+	case []interface{}:
+		ss := make([]string, 0, len(vs))
+		for _, e := range vs {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("not a string: %v", e)
+			}
+			ss = append(ss, s)
+		}
+		return ss, nil
+	}
+	return nil, fmt.Errorf("not an array: %v", v)
+}
@@ -0,0 +1,54 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetaData_Option(t *testing.T) {
+	md := metadataFinalNewline
+
+	assert.Equal(t, EnforceOption, md.Option("enforce"))
+	assert.Equal(t, SeverityOption, md.Option("severity"))
+	assert.Equal(t, "num", md.Option("num").Name)
+	assert.Nil(t, md.Option("nums"))
+	assert.Equal(t, []string{"enforce", "num", "severity"}, md.OptionNames())
+}
+
+func TestNewRule_Defaults(t *testing.T) {
+	linebreak, err := NewLinebreakRule(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, UnixStyleLinebreak, linebreak.(*LinebreakRule).Style)
+
+	finalNewline, err := NewFinalNewlineRule(map[string]interface{}{"enforce": true})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, finalNewline.(*FinalNewlineRule).Num)
+
+	finalNewline, err = NewFinalNewlineRule(map[string]interface{}{"num": 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, finalNewline.(*FinalNewlineRule).Num)
+}
+
+func TestRuleMap_GetAllRuleNames(t *testing.T) {
+	rmap := NewRuleMap(&NoBOMRule{}, &LinebreakRule{}, &FinalNewlineRule{})
+	assert.Equal(t, []string{"final-newline", "linebreak", "no-bom"}, rmap.GetAllRuleNames())
+}
+
+func TestMetaData_Examples(t *testing.T) {
+	for _, name := range GetDefinedRules().GetAllRuleNames() {
+		rule := GetDefinedRules().Get(name)
+		md := rule.MetaData()
+
+		assert.NotEmpty(t, md.Description, name)
+		assert.NotEmpty(t, md.Examples, name)
+		for _, ex := range md.Examples {
+			result, err := ex.Run(rule)
+			assert.NoError(t, err, name)
+			assert.NotEmpty(t, result.Reports, name+": examples should be reported")
+			if md.Fixable {
+				assert.NotEqual(t, ex.Source, string(result.Fixed), name+": examples should be fixed")
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// StreamRule is implemented by rules which can lint a file line by line
+// without loading the whole file into memory.
+// Rules don't fix files in streaming mode.
+type StreamRule interface {
+	Rule
+	LintStream(lr *LineReader) (*Result, error)
+}
+
+// maxLineLength is the max length of Line.Text.
+// Only the last maxLineLength bytes of longer lines are kept.
+const maxLineLength = 64 * 1024
+
+type Line struct {
+	// Num is the line number starting with 1
+	Num int
+
+	// Text is the line without the line break
+	Text []byte
+
+	// Linebreak is nil if the line is the last line without a line break
+	Linebreak LinebreakStyle
+
+	// Size is the bytes of the line including the line break, which is
+	// larger than Text if the line is longer than maxLineLength
+	Size int
+}
+
+// IsBlank reports whether the line has no characters except line breaks.
+func (l *Line) IsBlank() bool {
+	return len(bytes.Trim(l.Text, "\r")) == 0
+}
+
+// LineReader reads lines with bounded memory.
+type LineReader struct {
+	r   *bufio.Reader
+	num int
+}
+
+func NewLineReader(r io.Reader) *LineReader {
+	return &LineReader{r: bufio.NewReader(r)}
+}
+
+// Peek returns the next n bytes without advancing the reader.
+func (lr *LineReader) Peek(n int) []byte {
+	buf, _ := lr.r.Peek(n)
+	return buf
+}
+
+// Next returns the next line. It returns io.EOF if there are no more lines.
+func (lr *LineReader) Next() (*Line, error) {
+	var (
+		buf  []byte
+		read int
+	)
+
+	for {
+		chunk, err := lr.r.ReadSlice('\n')
+		read += len(chunk)
+		buf = append(buf, chunk...)
+
+		// keep a bit more than maxLineLength to keep the line break
+		if len(buf) > maxLineLength+2 {
+			n := copy(buf, buf[len(buf)-maxLineLength-2:])
+			buf = buf[:n]
+		}
+
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF {
+			if read == 0 {
+				return nil, io.EOF
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	lr.num++
+	line := &Line{Num: lr.num, Size: read}
+
+	switch {
+	case bytes.HasSuffix(buf, WindowsStyleLinebreak):
+		line.Linebreak = WindowsStyleLinebreak
+	case bytes.HasSuffix(buf, UnixStyleLinebreak):
+		line.Linebreak = UnixStyleLinebreak
+	}
+	buf = buf[:len(buf)-len(line.Linebreak)]
+
+	if len(buf) > maxLineLength {
+		buf = buf[len(buf)-maxLineLength:]
+	}
+	line.Text = buf
+
+	return line, nil
+}
+
+// forEachLine calls fn with each line read from lr.
+func forEachLine(lr *LineReader, fn func(l *Line)) error {
+	for {
+		l, err := lr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(l)
+	}
+}
@@ -0,0 +1,131 @@
+package lint
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineReader_Next(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []Line
+	}{
+		{
+			src:  "",
+			want: []Line{},
+		},
+		{
+			src: "a",
+			want: []Line{
+				{Num: 1, Text: []byte("a"), Size: 1},
+			},
+		},
+		{
+			src: "a\nb\r\n\n",
+			want: []Line{
+				{Num: 1, Text: []byte("a"), Linebreak: UnixStyleLinebreak, Size: 2},
+				{Num: 2, Text: []byte("b"), Linebreak: WindowsStyleLinebreak, Size: 3},
+				{Num: 3, Text: []byte{}, Linebreak: UnixStyleLinebreak, Size: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		lr := NewLineReader(strings.NewReader(tt.src))
+		got := []Line{}
+		for {
+			l, err := lr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, *l)
+		}
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestLineReader_LongLine(t *testing.T) {
+	src := strings.Repeat("a", maxLineLength*3) + " \n"
+	lr := NewLineReader(strings.NewReader(src))
+
+	l, err := lr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, l.Text, maxLineLength)
+	assert.True(t, bytes.HasSuffix(l.Text, []byte("a ")))
+	assert.Equal(t, UnixStyleLinebreak, l.Linebreak)
+
+	_, err = lr.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+// LintStream should report the same errors as Lint
+func TestStreamRule_LintStream(t *testing.T) {
+	rules := []StreamRule{
+		&LinebreakRule{Style: UnixStyleLinebreak},
+		&LinebreakRule{Style: WindowsStyleLinebreak},
+		&FirstNewlineRule{Num: 0},
+		&FirstNewlineRule{Num: 1},
+		&FinalNewlineRule{Num: 0},
+		&FinalNewlineRule{Num: 1},
+		&NoBOMRule{},
+		&NoEOLSpaceRule{},
+		&IndentRule{Style: TabIndent, Size: 4},
+		&IndentRule{Style: SpaceIndent, Size: 4},
+		&NoMultipleEmptyLinesRule{Max: 1},
+		&NoMultipleEmptyLinesRule{Max: 0, MaxBOF: 1, MaxEOF: 1},
+		&NoMultipleEmptyLinesRule{Max: 1, ctx: &Context{FileType: "markdown"}},
+		&UnicodeBidiRule{unicodeCharRule{chars: map[rune]string{'\u202E': "RIGHT-TO-LEFT OVERRIDE"}}},
+		&UnicodeMixedScriptRule{},
+		&NoSecretsRule{Detectors: secretDetectors["aws"], Entropy: true, EntropyMinLength: 20},
+		&NoConflictMarkersRule{},
+		&MaxSizeRule{Bytes: 4, Lines: 2, Minified: true, MinifiedLineLength: 3},
+		&NoEOLSpaceRule{ctx: &Context{FileType: "markdown"}},
+		&NoEOLSpaceRule{AllowMarkdownHardBreak: true, ctx: &Context{FileType: "markdown"}},
+		&NoEOLSpaceRule{SkipBlankLines: true, Whitespace: "unicode"},
+		&NoEOLSpaceRule{IgnoreComments: true, ctx: &Context{FileType: "css"}},
+		&NoEOLSpaceRule{IgnorePattern: regexp.MustCompile(`b\s`)},
+	}
+	srcs := []string{
+		"a",
+		"a\n",
+		"\n\na\n\n",
+		"\r\n\r\na \r\n\r\n",
+		"\xef\xbb\xbfa\n",
+		"a \nb\t\n c\n",
+		"\n",
+		"a\n\tb\n    c\n\t  d\n",
+		"\tb\r\n    c\r\n",
+		"a  \nb  \n\n```\nc  \n```\nd  ",
+		"---\n\n\n---\na\n\n\n```\n\n\n```\n\n\n",
+		"\n\na\n\n\n\nb\n \n\n",
+		"\r\na\r\n\r\n\r\nb",
+		"\u202Ea\r\nb\u202E \u0430b\n",
+		"<<<<<<< a\n>>>>>>> b\r\n>>>>>>>\n<<<<<<<",
+		"/* a \n * b \n */ \nc\u3000\n\u00a0\n",
+		"a\r\nid: " + testAWSAccessKeyID + "\r\n\"" + testHighEntropyString + "\"",
+	}
+
+	for _, rule := range rules {
+		for _, src := range srcs {
+			want, err := rule.Lint([]byte(src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := rule.LintStream(NewLineReader(strings.NewReader(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, want.Reports, got.Reports, "%s: %q", rule.MetaData().Name, src)
+		}
+	}
+}
@@ -2,7 +2,8 @@ package main
 
 import "github.com/synchro-food/filelint/cli"
 
-//go:generate go-bindata -pkg config -o config/bindata.go config/default.yml
+//go:generate go-bindata -pkg config -o config/bindata.go config/default.yml config/presets/
+//go:generate ./scripts/gen-rules-doc.sh
 
 func main() {
 	cli.Execute()
@@ -0,0 +1,19 @@
+#!/bin/bash
+# Regenerate the rule documentation in README.md from the metadata of the rules.
+# The documentation is placed between the RULES-BEGIN and RULES-END markers.
+
+set -e
+
+cd "$(dirname "$0")/.."
+
+docs=$(mktemp)
+trap 'rm -f "$docs"' EXIT
+
+go run main.go rules --format markdown > "$docs"
+
+awk -v docs="$docs" '
+/^<!-- RULES-BEGIN/ { print; print ""; while ((getline line < docs) > 0) print line; print ""; skip = 1; next }
+/^<!-- RULES-END/ { skip = 0 }
+!skip { print }
+' README.md > README.md.tmp
+mv README.md.tmp README.md